@@ -0,0 +1,84 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+import "math"
+
+// DistinctApprox returns an iterator producing values from the given iterator,
+// skipping values already produced, like Unique, but using a fixed amount of
+// memory. Values are identified by the given hash function, and seen values
+// are tracked using a Bloom filter sized for the expected number of distinct
+// items and the given false positive rate.
+//
+// Duplicates are always skipped, but distinct values can be skipped as well,
+// with a probability close to falsePositiveRate as long as the number of
+// distinct values does not exceed expectedItems. The probability increases
+// when more distinct values are produced. It panics if expectedItems is less
+// than 1 or if falsePositiveRate is not between 0 and 1 (excluded).
+//
+// For instance:
+//
+//	lines := it.DistinctApprox(it.Lines(r), func(v string) uint64 {
+//		h := fnv.New64a()
+//		h.Write([]byte(v))
+//		return h.Sum64()
+//	}, 1_000_000_000, 0.001)
+func DistinctApprox[T any](it Iterator[T], hash func(v T) uint64, expectedItems int, falsePositiveRate float64) Iterator[T] {
+	f := newBloomFilter(expectedItems, falsePositiveRate)
+	return Filter(it, func(v T) bool {
+		return f.add(hash(v))
+	})
+}
+
+// bloomFilter is a Bloom filter storing 64-bit hashes.
+type bloomFilter struct {
+	bits []uint64
+	m    uint64
+	k    int
+}
+
+// newBloomFilter returns a Bloom filter sized to hold n items with the given
+// false positive rate p.
+func newBloomFilter(n int, p float64) *bloomFilter {
+	if n < 1 || !(p > 0 && p < 1) {
+		panic("iterate: invalid bloom filter parameters")
+	}
+	// Compute the optimal number of bits m and hash functions k.
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := int(math.Round(m / float64(n) * math.Ln2))
+	return &bloomFilter{
+		bits: make([]uint64, (uint64(m)+63)/64),
+		m:    uint64(m),
+		k:    max(k, 1),
+	}
+}
+
+// add adds the given hash to the filter, and reports whether it was not
+// already present. False is returned for hashes that were never added with a
+// probability close to the false positive rate.
+func (f *bloomFilter) add(hash uint64) bool {
+	// Use double hashing for simulating k hash functions, mixing the hash so
+	// that poorly distributed hash functions can still be used.
+	h1 := mix(hash)
+	h2 := mix(hash^0x9e3779b97f4a7c15) | 1
+	added := false
+	for i := 0; i < f.k; i++ {
+		idx := (h1 + uint64(i)*h2) % f.m
+		word, mask := idx/64, uint64(1)<<(idx%64)
+		if f.bits[word]&mask == 0 {
+			f.bits[word] |= mask
+			added = true
+		}
+	}
+	return added
+}
+
+// mix is the splitmix64 finalizer.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,73 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"hash/fnv"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestDistinctApprox(t *testing.T) {
+	iter := it.DistinctApprox(it.FromSlice([]string{"a", "b", "a", "c", "b", "a"}), hashString, 100, 0.01)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []string{"a", "b", "c"}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), ""))
+}
+
+func TestDistinctApproxFalsePositiveRate(t *testing.T) {
+	const n = 100000
+	// Produce every value twice.
+	values := it.Chain(it.Count(0, n, 1), it.Count(0, n, 1))
+	iter := it.DistinctApprox(values, func(v int) uint64 {
+		// Poorly distributed hashes can be used.
+		return uint64(v)
+	}, n, 0.01)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+
+	// All duplicates are skipped, and only a few distinct values are dropped.
+	qt.Assert(t, qt.IsTrue(len(got) <= n))
+	dropped := n - len(got)
+	qt.Assert(t, qt.IsTrue(dropped < n*2/100), qt.Commentf("dropped %d values", dropped))
+	qt.Assert(t, qt.HasLen(uniqueInts(got), len(got)))
+}
+
+func TestDistinctApproxError(t *testing.T) {
+	iter := it.DistinctApprox[string](&errorIterator[string]{
+		v: "engage",
+	}, hashString, 10, 0.01)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []string{"engage"}))
+}
+
+func TestDistinctApproxInvalid(t *testing.T) {
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.DistinctApprox(it.FromSlice([]string{"a"}), hashString, 0, 0.01)
+	}, "iterate: invalid bloom filter parameters"))
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.DistinctApprox(it.FromSlice([]string{"a"}), hashString, 10, 1)
+	}, "iterate: invalid bloom filter parameters"))
+}
+
+func hashString(v string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(v))
+	return h.Sum64()
+}
+
+func uniqueInts(vs []int) map[int]bool {
+	m := make(map[int]bool, len(vs))
+	for _, v := range vs {
+		m[v] = true
+	}
+	return m
+}
@@ -2,25 +2,49 @@
 
 package iterate
 
+import "context"
+
 // FromChannel returns an iterator producing values from the given channel. The
 // iteration stops when the channel is closed. The returned error is always nil.
 func FromChannel[T any](ch <-chan T) Iterator[T] {
+	return FromChannelContext(context.Background(), ch)
+}
+
+// FromChannelContext returns an iterator producing values from the given
+// channel. The iteration stops when the channel is closed or when the context
+// is done, in which case pending receives are unblocked and ctx.Err() is
+// returned.
+func FromChannelContext[T any](ctx context.Context, ch <-chan T) Iterator[T] {
 	return &channelIterator[T]{
-		ch: ch,
+		ctx: ctx,
+		ch:  ch,
 	}
 }
 
 type channelIterator[T any] struct {
+	ctx   context.Context
 	ch    <-chan T
 	value T
+	err   error
 }
 
 // Next implements Iterator[T].Next.
 func (it *channelIterator[T]) Next() bool {
-	val, ok := <-it.ch
-	if ok {
-		it.value = val
-		return true
+	if it.err == nil {
+		it.err = it.ctx.Err()
+	}
+	if it.err != nil {
+		it.value = *new(T)
+		return false
+	}
+	select {
+	case val, ok := <-it.ch:
+		if ok {
+			it.value = val
+			return true
+		}
+	case <-it.ctx.Done():
+		it.err = it.ctx.Err()
 	}
 	it.value = *new(T)
 	return false
@@ -31,7 +55,8 @@ func (it *channelIterator[T]) Value() T {
 	return it.value
 }
 
-// Err implements Iterator[T].Err. The returned error is always nil.
+// Err implements Iterator[T].Err by returning the context error, if the context
+// is done.
 func (it *channelIterator[T]) Err() error {
-	return nil
+	return it.err
 }
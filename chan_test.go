@@ -3,6 +3,7 @@
 package iterate_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/go-quicktest/qt"
@@ -24,3 +25,23 @@ func TestFromChan(t *testing.T) {
 	qt.Assert(t, qt.IsNil(err))
 	qt.Assert(t, qt.DeepEquals(vs, []int{0, 1, 2, 3, 4}))
 }
+
+func TestFromChanContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan int)
+	iter := it.FromChannelContext(ctx, ch)
+	go func() {
+		ch <- 42
+		// Unblock the pending receive.
+		cancel()
+	}()
+
+	vs, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorIs(err, context.Canceled))
+	qt.Assert(t, qt.DeepEquals(vs, []int{42}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+}
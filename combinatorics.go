@@ -0,0 +1,431 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+import (
+	"errors"
+	"slices"
+)
+
+// Product returns an iterator producing the cartesian product of the values
+// produced by the given iterators, as slices holding one value from each
+// iterator. The values of the last iterator vary first, so that, if the
+// iterators produce sorted values, the slices are produced in lexicographic
+// order. A single empty slice is produced if no iterators are provided. The
+// iteration is stopped when any of the iterators has an error, in which case
+// the errors from all the iterators are returned.
+//
+// The first iterator is consumed only once, while values from the other
+// iterators are buffered in memory as they are retrieved, so that they can be
+// produced again. For this reason, the first iterator can be infinite, in
+// which case Limit can be used to stop the iteration.
+//
+// For instance:
+//
+//	values := it.Product(it.FromSlice([]string{"a", "b"}), it.FromSlice([]string{"x", "y", "z"}))
+//	for values.Next() {
+//		v := values.Value()
+//		// v is [a x], then [a y], then [a z], then [b x], then [b y], then [b z].
+//	}
+//
+// Each produced slice is newly allocated. See ProductReuse for avoiding
+// allocations.
+func Product[T any](its ...Iterator[T]) Iterator[[]T] {
+	return newProducer(its, false)
+}
+
+// ProductReuse is like Product, but the same slice is reused for all the
+// produced values, so that no allocations are required while iterating. For
+// this reason, the slice returned by Value is only valid until the next call
+// to Next.
+func ProductReuse[T any](its ...Iterator[T]) Iterator[[]T] {
+	return newProducer(its, true)
+}
+
+func newProducer[T any](its []Iterator[T], reuse bool) *producer[T] {
+	positions := make([]int, len(its))
+	for i := range positions {
+		positions[i] = -1
+	}
+	return &producer[T]{
+		sources:   its,
+		buffers:   make([][]T, len(its)),
+		exhausted: make([]bool, len(its)),
+		positions: positions,
+		current:   make([]T, len(its)),
+		reuse:     reuse,
+	}
+}
+
+type producer[T any] struct {
+	sources []Iterator[T]
+	// buffers holds the values retrieved so far from all the iterators except
+	// for the first one, and exhausted reports whether they have been fully
+	// consumed.
+	buffers   [][]T
+	exhausted []bool
+	// positions holds the buffer index of the current value for each
+	// iterator, and current the current values.
+	positions        []int
+	current          []T
+	reuse            bool
+	value            []T
+	started, stopped bool
+}
+
+// Next implements Iterator[T].Next.
+func (it *producer[T]) Next() bool {
+	if it.stopped {
+		return false
+	}
+	if !it.started {
+		it.started = true
+		for i := range it.sources {
+			if !it.advance(i) {
+				return it.done()
+			}
+		}
+		return it.produce()
+	}
+	for i := len(it.sources) - 1; i >= 0; i-- {
+		if it.advance(i) {
+			// Restart all the following iterators, which have been consumed
+			// in full at this point.
+			for j := i + 1; j < len(it.sources); j++ {
+				it.positions[j], it.current[j] = 0, it.buffers[j][0]
+			}
+			return it.produce()
+		}
+		if it.sources[i].Err() != nil {
+			break
+		}
+	}
+	return it.done()
+}
+
+// advance moves the iterator at the given index to its next value, reporting
+// whether a value is available.
+func (it *producer[T]) advance(idx int) bool {
+	source := it.sources[idx]
+	if idx == 0 {
+		// The first iterator is not buffered.
+		if !source.Next() {
+			return false
+		}
+		it.current[0] = source.Value()
+		return true
+	}
+	pos := it.positions[idx] + 1
+	if pos < len(it.buffers[idx]) {
+		it.positions[idx], it.current[idx] = pos, it.buffers[idx][pos]
+		return true
+	}
+	if it.exhausted[idx] || !source.Next() {
+		it.exhausted[idx] = true
+		return false
+	}
+	v := source.Value()
+	it.buffers[idx] = append(it.buffers[idx], v)
+	it.positions[idx], it.current[idx] = pos, v
+	return true
+}
+
+// produce sets the current value.
+func (it *producer[T]) produce() bool {
+	if it.reuse {
+		it.value = it.current
+	} else {
+		it.value = slices.Clone(it.current)
+	}
+	return true
+}
+
+// done stops the iteration.
+func (it *producer[T]) done() bool {
+	it.value, it.stopped = nil, true
+	it.buffers, it.current = nil, nil
+	return false
+}
+
+// Value implements Iterator[T].Value by returning slices of values.
+func (it *producer[T]) Value() []T {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by joining the errors from all the source
+// iterators.
+func (it *producer[T]) Err() error {
+	errs := make([]error, 0, len(it.sources))
+	for _, source := range it.sources {
+		errs = append(errs, source.Err())
+	}
+	return errors.Join(errs...)
+}
+
+// Close implements IteratorCloser[T].Close by closing all the source
+// iterators.
+func (it *producer[T]) Close() error {
+	return closeAll(it.sources)
+}
+
+// Permutations returns an iterator producing all the permutations of length r
+// of the values in the given slice. Values are treated as unique based on
+// their position, not on their value. Permutations are produced in
+// lexicographic order of positions. No permutations are produced if r is
+// greater than the length of the slice. It panics if r is negative.
+//
+// For instance:
+//
+//	values := it.Permutations([]string{"a", "b", "c"}, 2)
+//	// values produces [a b], [a c], [b a], [b c], [c a], [c b].
+//
+// Each produced slice is newly allocated. See PermutationsReuse for avoiding
+// allocations.
+func Permutations[T any](s []T, r int) Iterator[[]T] {
+	return newPermutator(s, r, false)
+}
+
+// PermutationsReuse is like Permutations, but the same slice is reused for all
+// the produced values, so that no allocations are required while iterating.
+// For this reason, the slice returned by Value is only valid until the next
+// call to Next.
+func PermutationsReuse[T any](s []T, r int) Iterator[[]T] {
+	return newPermutator(s, r, true)
+}
+
+func newPermutator[T any](s []T, r int, reuse bool) *combinator[T] {
+	n := len(s)
+	used := make([]bool, n)
+	for i := 0; i < r && i < n; i++ {
+		used[i] = true
+	}
+	return newCombinator(s, r, r <= n, reuse, func(indices []int) bool {
+		for i := len(indices) - 1; i >= 0; i-- {
+			used[indices[i]] = false
+			for v := indices[i] + 1; v < n; v++ {
+				if used[v] {
+					continue
+				}
+				indices[i], used[v] = v, true
+				// Fill the following positions with the smallest unused
+				// indices.
+				k := 0
+				for j := i + 1; j < len(indices); j++ {
+					for used[k] {
+						k++
+					}
+					indices[j], used[k] = k, true
+				}
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Combinations returns an iterator producing all the combinations of length r
+// of the values in the given slice. Values are treated as unique based on
+// their position, not on their value, and are produced in their original
+// order. Combinations are produced in lexicographic order of positions. No
+// combinations are produced if r is greater than the length of the slice. It
+// panics if r is negative.
+//
+// For instance:
+//
+//	values := it.Combinations([]string{"a", "b", "c", "d"}, 2)
+//	// values produces [a b], [a c], [a d], [b c], [b d], [c d].
+//
+// Each produced slice is newly allocated. See CombinationsReuse for avoiding
+// allocations.
+func Combinations[T any](s []T, r int) Iterator[[]T] {
+	return newCombinations(s, r, false)
+}
+
+// CombinationsReuse is like Combinations, but the same slice is reused for all
+// the produced values, so that no allocations are required while iterating.
+// For this reason, the slice returned by Value is only valid until the next
+// call to Next.
+func CombinationsReuse[T any](s []T, r int) Iterator[[]T] {
+	return newCombinations(s, r, true)
+}
+
+func newCombinations[T any](s []T, r int, reuse bool) *combinator[T] {
+	n := len(s)
+	return newCombinator(s, r, r <= n, reuse, func(indices []int) bool {
+		return nextCombination(indices, n)
+	})
+}
+
+// nextCombination advances the given indices to the next combination of
+// indices lower than n, reporting whether there are more combinations.
+func nextCombination(indices []int, n int) bool {
+	r := len(indices)
+	for i := r - 1; i >= 0; i-- {
+		if indices[i] == i+n-r {
+			continue
+		}
+		indices[i]++
+		for j := i + 1; j < r; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+		return true
+	}
+	return false
+}
+
+// CombinationsWithReplacement is like Combinations, but values can be
+// repeated in the produced combinations.
+//
+// For instance:
+//
+//	values := it.CombinationsWithReplacement([]string{"a", "b", "c"}, 2)
+//	// values produces [a a], [a b], [a c], [b b], [b c], [c c].
+//
+// Each produced slice is newly allocated. See
+// CombinationsWithReplacementReuse for avoiding allocations.
+func CombinationsWithReplacement[T any](s []T, r int) Iterator[[]T] {
+	return newCombinationsWithReplacement(s, r, false)
+}
+
+// CombinationsWithReplacementReuse is like CombinationsWithReplacement, but
+// the same slice is reused for all the produced values, so that no
+// allocations are required while iterating. For this reason, the slice
+// returned by Value is only valid until the next call to Next.
+func CombinationsWithReplacementReuse[T any](s []T, r int) Iterator[[]T] {
+	return newCombinationsWithReplacement(s, r, true)
+}
+
+func newCombinationsWithReplacement[T any](s []T, r int, reuse bool) *combinator[T] {
+	n := len(s)
+	c := newCombinator(s, r, n > 0 || r == 0, reuse, func(indices []int) bool {
+		for i := len(indices) - 1; i >= 0; i-- {
+			if indices[i] == n-1 {
+				continue
+			}
+			indices[i]++
+			for j := i + 1; j < len(indices); j++ {
+				indices[j] = indices[i]
+			}
+			return true
+		}
+		return false
+	})
+	clear(c.indices)
+	return c
+}
+
+// PowerSet returns an iterator producing all the subsets of the values in
+// the given slice, from the empty one to the one including all the values.
+// Subsets are produced in order of length, and then in the order used by
+// Combinations.
+//
+// For instance:
+//
+//	values := it.PowerSet([]string{"a", "b", "c"})
+//	// values produces [], [a], [b], [c], [a b], [a c], [b c], [a b c].
+//
+// Each produced slice is newly allocated. See PowerSetReuse for avoiding
+// allocations.
+func PowerSet[T any](s []T) Iterator[[]T] {
+	return newPowerSet(s, false)
+}
+
+// PowerSetReuse is like PowerSet, but the same slice is reused for all the
+// produced values, so that no allocations are required while iterating. For
+// this reason, the slice returned by Value is only valid until the next call
+// to Next.
+func PowerSetReuse[T any](s []T) Iterator[[]T] {
+	return newPowerSet(s, true)
+}
+
+func newPowerSet[T any](s []T, reuse bool) *combinator[T] {
+	n := len(s)
+	c := newCombinator(s, n, true, reuse, nil)
+	c.indices = c.indices[:0]
+	c.next = func(indices []int) bool {
+		if nextCombination(indices, n) {
+			return true
+		}
+		r := len(indices) + 1
+		if r > n {
+			return false
+		}
+		// Move to the first combination of the next length.
+		c.indices = c.indices[:r]
+		for i := range c.indices {
+			c.indices[i] = i
+		}
+		return true
+	}
+	return c
+}
+
+// newCombinator returns a combinator producing values from the given slice
+// selected by indices, with the initial indices set to [0, r). No values are
+// produced if ok is false.
+func newCombinator[T any](s []T, r int, ok, reuse bool, next func(indices []int) bool) *combinator[T] {
+	if r < 0 {
+		panic("iterate: invalid length")
+	}
+	c := &combinator[T]{
+		pool:    s,
+		next:    next,
+		reuse:   reuse,
+		stopped: !ok,
+	}
+	if ok {
+		c.indices = make([]int, r)
+		for i := range c.indices {
+			c.indices[i] = i
+		}
+	}
+	return c
+}
+
+// combinator is an iterator producing values from a slice selected by indices.
+type combinator[T any] struct {
+	pool    []T
+	indices []int
+	// next advances the indices in place, reporting whether there are more
+	// values to produce.
+	next             func(indices []int) bool
+	reuse            bool
+	value            []T
+	started, stopped bool
+}
+
+// Next implements Iterator[T].Next.
+func (it *combinator[T]) Next() bool {
+	if it.stopped {
+		return false
+	}
+	if it.started && !it.next(it.indices) {
+		it.value, it.stopped = nil, true
+		return false
+	}
+	it.started = true
+	var value []T
+	switch {
+	case !it.reuse:
+		value = make([]T, len(it.indices))
+	case it.value == nil:
+		value = make([]T, len(it.indices), cap(it.indices))
+	default:
+		value = it.value[:len(it.indices)]
+	}
+	for i, idx := range it.indices {
+		value[i] = it.pool[idx]
+	}
+	it.value = value
+	return true
+}
+
+// Value implements Iterator[T].Value by returning slices of values.
+func (it *combinator[T]) Value() []T {
+	return it.value
+}
+
+// Err implements Iterator[T].Err. The returned error is always nil.
+func (it *combinator[T]) Err() error {
+	return nil
+}
@@ -0,0 +1,233 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestProduct(t *testing.T) {
+	iter := it.Product(it.Count(0, 2, 1), it.Count(10, 13, 1), it.Count(20, 22, 1))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, [][]int{
+		{0, 10, 20}, {0, 10, 21}, {0, 11, 20}, {0, 11, 21}, {0, 12, 20}, {0, 12, 21},
+		{1, 10, 20}, {1, 10, 21}, {1, 11, 20}, {1, 11, 21}, {1, 12, 20}, {1, 12, 21},
+	}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.IsNil(iter.Value()))
+}
+
+func TestProductEmpty(t *testing.T) {
+	got, err := it.ToSlice(it.Product(it.Count(0, 2, 1), it.Count(0, 0, 1)))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.IsNil(got))
+
+	got, err = it.ToSlice(it.Product[int]())
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, [][]int{{}}))
+}
+
+func TestProductInfinite(t *testing.T) {
+	iter := it.Limit(it.Product(it.Infinite(0, 1), it.FromSlice([]int{0, 1})), 5)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, [][]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}, {2, 0}}))
+}
+
+func TestProductReuse(t *testing.T) {
+	iter := it.ProductReuse(it.Count(0, 2, 1), it.Count(0, 2, 1))
+	var got [][]int
+	var first []int
+	for iter.Next() {
+		v := iter.Value()
+		if first == nil {
+			first = v
+		}
+		// The same underlying array is reused.
+		qt.Assert(t, qt.Equals(&v[0], &first[0]))
+		got = append(got, append([]int(nil), v...))
+	}
+	qt.Assert(t, qt.IsNil(iter.Err()))
+	qt.Assert(t, qt.DeepEquals(got, [][]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}}))
+}
+
+func TestProductError(t *testing.T) {
+	inner := it.Chain[int](it.FromSlice([]int{0}), &errorIterator[int]{v: 1})
+	got, err := it.ToSlice(it.Product(it.Count(0, 2, 1), inner))
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, [][]int{{0, 0}, {0, 1}}))
+
+	outer := &errIterator[int]{err: errors.New("bad wolf")}
+	got, err = it.ToSlice(it.Product[int](outer, it.Count(0, 2, 1)))
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.IsNil(got))
+}
+
+var combinatoricsTests = []struct {
+	about string
+	iter  func() it.Iterator[[]string]
+	want  [][]string
+}{{
+	about: "permutations",
+	iter: func() it.Iterator[[]string] {
+		return it.Permutations([]string{"a", "b", "c"}, 2)
+	},
+	want: [][]string{{"a", "b"}, {"a", "c"}, {"b", "a"}, {"b", "c"}, {"c", "a"}, {"c", "b"}},
+}, {
+	about: "permutations full length",
+	iter: func() it.Iterator[[]string] {
+		return it.Permutations([]string{"a", "b", "c"}, 3)
+	},
+	want: [][]string{
+		{"a", "b", "c"}, {"a", "c", "b"}, {"b", "a", "c"},
+		{"b", "c", "a"}, {"c", "a", "b"}, {"c", "b", "a"},
+	},
+}, {
+	about: "permutations zero length",
+	iter: func() it.Iterator[[]string] {
+		return it.Permutations([]string{"a", "b"}, 0)
+	},
+	want: [][]string{{}},
+}, {
+	about: "permutations too long",
+	iter: func() it.Iterator[[]string] {
+		return it.Permutations([]string{"a", "b"}, 3)
+	},
+	want: nil,
+}, {
+	about: "combinations",
+	iter: func() it.Iterator[[]string] {
+		return it.Combinations([]string{"a", "b", "c", "d"}, 2)
+	},
+	want: [][]string{{"a", "b"}, {"a", "c"}, {"a", "d"}, {"b", "c"}, {"b", "d"}, {"c", "d"}},
+}, {
+	about: "combinations full length",
+	iter: func() it.Iterator[[]string] {
+		return it.Combinations([]string{"a", "b", "c"}, 3)
+	},
+	want: [][]string{{"a", "b", "c"}},
+}, {
+	about: "combinations too long",
+	iter: func() it.Iterator[[]string] {
+		return it.Combinations([]string{"a", "b"}, 3)
+	},
+	want: nil,
+}, {
+	about: "combinations with replacement",
+	iter: func() it.Iterator[[]string] {
+		return it.CombinationsWithReplacement([]string{"a", "b", "c"}, 2)
+	},
+	want: [][]string{{"a", "a"}, {"a", "b"}, {"a", "c"}, {"b", "b"}, {"b", "c"}, {"c", "c"}},
+}, {
+	about: "combinations with replacement longer than slice",
+	iter: func() it.Iterator[[]string] {
+		return it.CombinationsWithReplacement([]string{"a", "b"}, 3)
+	},
+	want: [][]string{{"a", "a", "a"}, {"a", "a", "b"}, {"a", "b", "b"}, {"b", "b", "b"}},
+}, {
+	about: "combinations with replacement empty slice",
+	iter: func() it.Iterator[[]string] {
+		return it.CombinationsWithReplacement([]string{}, 2)
+	},
+	want: nil,
+}, {
+	about: "power set",
+	iter: func() it.Iterator[[]string] {
+		return it.PowerSet([]string{"a", "b", "c"})
+	},
+	want: [][]string{
+		{}, {"a"}, {"b"}, {"c"}, {"a", "b"}, {"a", "c"}, {"b", "c"}, {"a", "b", "c"},
+	},
+}, {
+	about: "power set empty slice",
+	iter: func() it.Iterator[[]string] {
+		return it.PowerSet([]string{})
+	},
+	want: [][]string{{}},
+}}
+
+func TestCombinatorics(t *testing.T) {
+	for _, test := range combinatoricsTests {
+		t.Run(test.about, func(t *testing.T) {
+			iter := test.iter()
+			got, err := it.ToSlice(iter)
+			qt.Assert(t, qt.IsNil(err))
+			qt.Assert(t, qt.DeepEquals(got, test.want))
+
+			// Further calls to next return false and produce the zero value.
+			qt.Assert(t, qt.IsFalse(iter.Next()))
+			qt.Assert(t, qt.IsNil(iter.Value()))
+		})
+	}
+}
+
+func TestCombinatoricsReuse(t *testing.T) {
+	tests := []struct {
+		about string
+		iter  it.Iterator[[]int]
+		want  [][]int
+	}{{
+		about: "permutations",
+		iter:  it.PermutationsReuse([]int{1, 2, 3}, 2),
+		want:  [][]int{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}},
+	}, {
+		about: "combinations",
+		iter:  it.CombinationsReuse([]int{1, 2, 3}, 2),
+		want:  [][]int{{1, 2}, {1, 3}, {2, 3}},
+	}, {
+		about: "combinations with replacement",
+		iter:  it.CombinationsWithReplacementReuse([]int{1, 2}, 2),
+		want:  [][]int{{1, 1}, {1, 2}, {2, 2}},
+	}, {
+		about: "power set",
+		iter:  it.PowerSetReuse([]int{1, 2}),
+		want:  [][]int{{}, {1}, {2}, {1, 2}},
+	}}
+	for _, test := range tests {
+		t.Run(test.about, func(t *testing.T) {
+			var got [][]int
+			var first []int
+			for test.iter.Next() {
+				v := test.iter.Value()
+				if first == nil {
+					first = v
+				}
+				// The same underlying array is reused.
+				qt.Assert(t, qt.Equals(&v[:cap(v)][0], &first[:cap(first)][0]))
+				got = append(got, append([]int{}, v...))
+			}
+			qt.Assert(t, qt.IsNil(test.iter.Err()))
+			qt.Assert(t, qt.DeepEquals(got, test.want))
+		})
+	}
+}
+
+func TestCombinatoricsLimit(t *testing.T) {
+	// Huge spaces can be explored lazily.
+	s := make([]int, 100)
+	for i := range s {
+		s[i] = i
+	}
+	got, err := it.ToSlice(it.Limit(it.Permutations(s, 100), 2))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.HasLen(got, 2))
+	qt.Assert(t, qt.DeepEquals(got[0][98:], []int{98, 99}))
+	qt.Assert(t, qt.DeepEquals(got[1][98:], []int{99, 98}))
+}
+
+func TestCombinatoricsInvalidLength(t *testing.T) {
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.Combinations([]int{1}, -1)
+	}, "iterate: invalid length"))
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.Permutations([]int{1}, -1)
+	}, "iterate: invalid length"))
+}
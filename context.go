@@ -0,0 +1,59 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+import "context"
+
+// WithContext returns an iterator producing values from the given iterator
+// until the context is done, in which case Next returns false and Err returns
+// ctx.Err().
+//
+// The context is checked before advancing the source iterator, so a call to
+// Next already blocked in the source iterator is not interrupted. Use context
+// aware constructors like FromChannelContext or LinesContext for that.
+func WithContext[T any](ctx context.Context, it Iterator[T]) Iterator[T] {
+	return &contextIterator[T]{
+		ctx:    ctx,
+		source: it,
+	}
+}
+
+type contextIterator[T any] struct {
+	ctx    context.Context
+	source Iterator[T]
+	err    error
+}
+
+// Next implements Iterator[T].Next.
+func (it *contextIterator[T]) Next() bool {
+	if it.err == nil {
+		it.err = it.ctx.Err()
+	}
+	if it.err != nil {
+		return false
+	}
+	return it.source.Next()
+}
+
+// Value implements Iterator[T].Value by returning values from the source
+// iterator while the context is not done.
+func (it *contextIterator[T]) Value() T {
+	if it.err != nil {
+		return *new(T)
+	}
+	return it.source.Value()
+}
+
+// Err implements Iterator[T].Err by returning the context error, if the context
+// is done, or by propagating the error from the source iterator.
+func (it *contextIterator[T]) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.source.Err()
+}
+
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *contextIterator[T]) Close() error {
+	return Close(it.source)
+}
@@ -0,0 +1,44 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	iter := it.WithContext(ctx, it.Count(0, 10, 1))
+
+	vs, err := it.ToSlice(it.Limit(iter, 3))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(vs, []int{0, 1, 2}))
+
+	cancel()
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+	qt.Assert(t, qt.ErrorIs(iter.Err(), context.Canceled))
+}
+
+func TestWithContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	vs, err := it.ToSlice(it.WithContext(ctx, it.Count(0, 10, 1)))
+	qt.Assert(t, qt.ErrorIs(err, context.Canceled))
+	qt.Assert(t, qt.IsNil(vs))
+}
+
+func TestWithContextError(t *testing.T) {
+	iter := it.WithContext[string](context.Background(), &errorIterator[string]{
+		v: "engage",
+	})
+	vs, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(vs, []string{"engage"}))
+}
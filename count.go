@@ -2,10 +2,19 @@
 
 package iterate
 
-import "golang.org/x/exp/constraints"
+import (
+	"errors"
 
-// Count returns an iterator counting consecutive values from start to stop with
-// the given step. The returned error is always nil.
+	"golang.org/x/exp/constraints"
+)
+
+// ErrOverflow is returned by numeric iterators when the next value cannot be
+// represented by the numeric type.
+var ErrOverflow = errors.New("iterate: numeric overflow")
+
+// Count returns an iterator counting consecutive values from start to stop
+// (excluded) with the given step. It is equivalent to Range for integers, and
+// the returned error is always nil.
 //
 // For instance:
 //
@@ -16,35 +25,122 @@ import "golang.org/x/exp/constraints"
 //     }
 //
 func Count(start, stop, step int) Iterator[int] {
-	return &counter{
-		start: start - step,
-		stop:  stop,
+	return Range(start, stop, step)
+}
+
+// Range returns an iterator producing numbers from start to stop (excluded)
+// with the given step, which can be negative. The iteration stops as soon as
+// the next value would reach or exceed stop, so stop is not required to be
+// reachable exactly. No values are produced if step is zero.
+//
+// For instance:
+//
+//	numbers := it.Range(0, 10, 3)
+//	for numbers.Next() {
+//		v := numbers.Value()
+//		// v is 0, then 3, then 6, then 9.
+//	}
+//
+// With floating point numbers, each value is computed as start + i*step, so
+// that rounding errors do not accumulate over the iteration. ErrOverflow is
+// returned if the step is too small for the next value to be different from
+// the current one.
+func Range[N constraints.Integer | constraints.Float](start, stop, step N) Iterator[N] {
+	return &ranger[N]{
+		start:   start,
+		stop:    stop,
+		step:    step,
+		bounded: true,
+		float:   isFloat[N](),
+	}
+}
+
+// Infinite returns an iterator producing numbers from start endlessly with the
+// given step, which can be negative. The iteration stops with ErrOverflow if
+// the next value cannot be represented.
+func Infinite[N constraints.Integer | constraints.Float](start, step N) Iterator[N] {
+	return &ranger[N]{
+		start: start,
 		step:  step,
+		float: isFloat[N](),
 	}
 }
 
-type counter struct {
-	start, stop, step int
+// isFloat reports whether N is a floating point type.
+func isFloat[N constraints.Integer | constraints.Float]() bool {
+	return N(1)/N(2) != 0
+}
+
+type ranger[N constraints.Integer | constraints.Float] struct {
+	start, stop, step N
+	bounded, float    bool
+	// idx is the number of steps taken from start.
+	idx              int
+	value            N
+	started, stopped bool
+	err              error
 }
 
 // Next implements Iterator[T].Next.
-func (it *counter) Next() bool {
-	it.start += it.step
-	if it.start == it.stop {
-		it.start, it.stop = 0, it.step
+func (it *ranger[N]) Next() bool {
+	if it.stopped {
 		return false
 	}
+	if !it.started {
+		it.started = true
+		it.value = it.start
+		if it.bounded && !it.inRange(it.value) {
+			return it.done(nil)
+		}
+		return true
+	}
+	var next N
+	if it.float {
+		// Compute floating point values from start, so that rounding errors
+		// do not accumulate.
+		it.idx++
+		next = it.start + N(it.idx)*it.step
+	} else {
+		next = it.value + it.step
+	}
+	switch {
+	case it.step > 0 && next < it.value, it.step < 0 && next > it.value:
+		// Integer wrap around: when bounded, the next value would be beyond
+		// stop anyway.
+		if it.bounded {
+			return it.done(nil)
+		}
+		return it.done(ErrOverflow)
+	case it.bounded && !it.inRange(next):
+		return it.done(nil)
+	case it.step != 0 && next == it.value, next-next != 0:
+		// Floating point precision loss or infinity.
+		return it.done(ErrOverflow)
+	}
+	it.value = next
 	return true
 }
 
-// Value implements Iterator[T].Value by returning the next number in the count.
-func (it *counter) Value() int {
-	return it.start
+// inRange reports whether the given value is before stop.
+func (it *ranger[N]) inRange(v N) bool {
+	return it.step > 0 && v < it.stop || it.step < 0 && v > it.stop
+}
+
+// done stops the iteration with the given error.
+func (it *ranger[N]) done(err error) bool {
+	it.value, it.stopped, it.err = 0, true, err
+	return false
 }
 
-// Err implements Iterator[T].Err. The returned error is always nil.
-func (it *counter) Err() error {
-	return nil
+// Value implements Iterator[T].Value by returning the current number.
+func (it *ranger[N]) Value() N {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by returning ErrOverflow if the next value
+// cannot be represented.
+func (it *ranger[N]) Err() error {
+	return it.err
 }
 
 // Enumerate returns an iterator that produces key/value pairs in which the keys
@@ -60,6 +156,12 @@ func (it *counter) Err() error {
 //     }
 //
 func Enumerate[T any](it Iterator[T]) Iterator[KeyValue[int, T]] {
+	return ToKeyValues(EnumeratePairs(it))
+}
+
+// EnumeratePairs is like Enumerate, but it produces pairs instead of key/value
+// pairs.
+func EnumeratePairs[T any](it Iterator[T]) Iterator[Pair[int, T]] {
 	return &enumerator[T]{
 		source: it,
 		idx:    -1,
@@ -82,10 +184,10 @@ func (it *enumerator[T]) Next() bool {
 }
 
 // Value implements Iterator[T].Next enumerating values from the source iterator.
-func (it *enumerator[T]) Value() KeyValue[int, T] {
-	return KeyValue[int, T]{
-		Key:   it.idx,
-		Value: it.source.Value(),
+func (it *enumerator[T]) Value() Pair[int, T] {
+	return Pair[int, T]{
+		First:  it.idx,
+		Second: it.source.Value(),
 	}
 }
 
@@ -95,6 +197,11 @@ func (it *enumerator[T]) Err() error {
 	return it.source.Err()
 }
 
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *enumerator[T]) Close() error {
+	return Close(it.source)
+}
+
 // Sum sums the values produced by the given iterator.
 //
 // For instance:
@@ -4,6 +4,7 @@ package iterate_test
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/frankban/iterate"
@@ -57,6 +58,125 @@ func TestCountSkipValue(t *testing.T) {
 	qt.Assert(t, qt.IsNil(counter.Err()))
 }
 
+func TestCountOvershoot(t *testing.T) {
+	got, err := it.ToSlice(it.Count(0, 10, 3))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{0, 3, 6, 9}))
+
+	got, err = it.ToSlice(it.Count(0, -10, -4))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{0, -4, -8}))
+}
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		start, stop, step int8
+		want              []int8
+	}{{
+		start: 0,
+		stop:  10,
+		step:  3,
+		want:  []int8{0, 3, 6, 9},
+	}, {
+		start: 10,
+		stop:  0,
+		step:  -4,
+		want:  []int8{10, 6, 2},
+	}, {
+		start: 0,
+		stop:  10,
+		step:  -1,
+		want:  nil,
+	}, {
+		start: 5,
+		stop:  5,
+		step:  1,
+		want:  nil,
+	}, {
+		start: 0,
+		stop:  10,
+		step:  0,
+		want:  nil,
+	}, {
+		start: 120,
+		stop:  127,
+		step:  5,
+		want:  []int8{120, 125},
+	}, {
+		start: -120,
+		stop:  -128,
+		step:  -5,
+		want:  []int8{-120, -125},
+	}}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d, %d, %d", test.start, test.stop, test.step), func(t *testing.T) {
+			iter := it.Range(test.start, test.stop, test.step)
+			got, err := it.ToSlice(iter)
+			qt.Assert(t, qt.IsNil(err))
+			qt.Assert(t, qt.DeepEquals(got, test.want))
+
+			// Further calls to next return false and produce the zero value.
+			qt.Assert(t, qt.IsFalse(iter.Next()))
+			qt.Assert(t, qt.Equals(iter.Value(), 0))
+		})
+	}
+}
+
+func TestRangeFloat(t *testing.T) {
+	got, err := it.ToSlice(it.Range(0, 1, 0.25))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []float64{0, 0.25, 0.5, 0.75}))
+
+	got, err = it.ToSlice(it.Range(1, 0, -0.5))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []float64{1, 0.5}))
+
+	// Rounding errors do not accumulate.
+	got, err = it.ToSlice(it.Range(0, 1, 0.1))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.HasLen(got, 10))
+	qt.Assert(t, qt.Equals(got[9], 0.9))
+
+	got, err = it.ToSlice(it.Range(1, 0, -0.1))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.HasLen(got, 10))
+	qt.Assert(t, qt.IsTrue(got[9] > 0.0999 && got[9] < 0.1001))
+
+	fs, err := it.ToSlice(it.Range[float32](0, 3, 0.3))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.HasLen(fs, 10))
+	qt.Assert(t, qt.Equals(fs[9], 2.7))
+}
+
+func TestRangeFloatOverflow(t *testing.T) {
+	// The step is too small to make progress.
+	iter := it.Range[float64](1e20, 2e20, 1)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorIs(err, it.ErrOverflow))
+	qt.Assert(t, qt.DeepEquals(got, []float64{1e20}))
+}
+
+func TestInfinite(t *testing.T) {
+	got, err := it.ToSlice(it.Limit(it.Infinite(10, -3), 5))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{10, 7, 4, 1, -2}))
+}
+
+func TestInfiniteOverflow(t *testing.T) {
+	iter := it.Infinite[uint8](250, 2)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorIs(err, it.ErrOverflow))
+	qt.Assert(t, qt.DeepEquals(got, []uint8{250, 252, 254}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+
+	fs, err := it.ToSlice(it.Infinite(math.MaxFloat64/2, math.MaxFloat64/2))
+	qt.Assert(t, qt.ErrorIs(err, it.ErrOverflow))
+	qt.Assert(t, qt.DeepEquals(fs, []float64{math.MaxFloat64 / 2, math.MaxFloat64}))
+}
+
 func TestEnumerate(t *testing.T) {
 	iter := it.Enumerate(it.FromSlice([]string{"a", "b", "c"}))
 	got, err := it.ToSlice(iter)
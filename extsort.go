@@ -0,0 +1,413 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+	"sort"
+)
+
+// defaultMaxChunkValues is the default maximum number of values sorted in
+// memory by SortExternal.
+const defaultMaxChunkValues = 1 << 16
+
+// defaultMaxOpenFiles is the default maximum number of temporary files merged
+// at once by SortExternal.
+const defaultMaxOpenFiles = 128
+
+// Codec creates encoders and decoders used for storing values in files.
+type Codec[T any] interface {
+	// NewEncoder returns an encoder writing values to w.
+	NewEncoder(w io.Writer) Encoder[T]
+	// NewDecoder returns a decoder reading values from r.
+	NewDecoder(r io.Reader) Decoder[T]
+}
+
+// Encoder writes values to an underlying stream.
+type Encoder[T any] interface {
+	// Encode writes the given value.
+	Encode(v T) error
+}
+
+// Decoder reads values from an underlying stream.
+type Decoder[T any] interface {
+	// Decode reads the next value. It returns io.EOF when there are no more
+	// values to read.
+	Decode() (T, error)
+}
+
+// GobCodec returns a codec storing values using encoding/gob.
+func GobCodec[T any]() Codec[T] {
+	return gobCodec[T]{}
+}
+
+type gobCodec[T any] struct{}
+
+// NewEncoder implements Codec[T].NewEncoder.
+func (gobCodec[T]) NewEncoder(w io.Writer) Encoder[T] {
+	return gobEncoder[T]{
+		enc: gob.NewEncoder(w),
+	}
+}
+
+// NewDecoder implements Codec[T].NewDecoder.
+func (gobCodec[T]) NewDecoder(r io.Reader) Decoder[T] {
+	return gobDecoder[T]{
+		dec: gob.NewDecoder(r),
+	}
+}
+
+type gobEncoder[T any] struct {
+	enc *gob.Encoder
+}
+
+// Encode implements Encoder[T].Encode.
+func (e gobEncoder[T]) Encode(v T) error {
+	return e.enc.Encode(v)
+}
+
+type gobDecoder[T any] struct {
+	dec *gob.Decoder
+}
+
+// Decode implements Decoder[T].Decode.
+func (d gobDecoder[T]) Decode() (T, error) {
+	var v T
+	err := d.dec.Decode(&v)
+	return v, err
+}
+
+// SortExternalOptions holds options for SortExternal.
+type SortExternalOptions[T any] struct {
+	// MaxChunkValues is the maximum number of values held and sorted in
+	// memory at once. If both MaxChunkValues and MaxChunkBytes are zero, it
+	// defaults to 65536 values. Zero means no limit otherwise.
+	MaxChunkValues int
+
+	// MaxChunkBytes, if not zero, is the maximum amount of memory, in bytes,
+	// used by the values held and sorted in memory at once, as measured by
+	// Size. A chunk can exceed the limit by at most one value. Use this
+	// instead of MaxChunkValues when values have large or variable sizes.
+	MaxChunkBytes int
+
+	// Size returns the number of bytes used by the given value. It is
+	// required when MaxChunkBytes is set, and ignored otherwise.
+	Size func(v T) int
+
+	// MaxOpenFiles is the maximum number of temporary files read at once when
+	// merging sorted chunks. When there are more chunks, they are merged in
+	// multiple passes, each one storing groups of merged chunks in new
+	// temporary files. It must be at least 2, and defaults to 128 if zero.
+	MaxOpenFiles int
+
+	// Dir is the directory where temporary files are created. If empty, the
+	// default directory for temporary files is used, as returned by
+	// os.TempDir.
+	Dir string
+}
+
+// SortExternal returns an iterator producing the values from the given
+// iterator sorted according to the given less function. Values comparing
+// equal are produced in their original order.
+//
+// Unlike sorting a slice, SortExternal can sort more values than the available
+// memory: values are collected in chunks limited by opts.MaxChunkValues and
+// opts.MaxChunkBytes, and each chunk is sorted and stored in a temporary file
+// using the given codec, or GobCodec if the codec is nil. Sorted chunks are
+// then lazily merged when the returned iterator is consumed. No temporary
+// files are created when all values fit in a single chunk, and at most
+// opts.MaxOpenFiles temporary files are open at once for reading. It panics if
+// the chunk limits are negative, if MaxChunkBytes is set without Size, or if
+// MaxOpenFiles is negative or 1.
+//
+// The source iterator is consumed in full on the first call to Next.
+// Temporary files are removed when the iteration is done, when an error
+// occurs or when the iterator is closed.
+//
+// For instance:
+//
+//	lines := it.SortExternal(it.Lines(f), func(a, b string) bool {
+//		return a < b
+//	}, nil, it.SortExternalOptions[string]{
+//		MaxChunkBytes: 64 << 20,
+//		Size: func(v string) int {
+//			return len(v)
+//		},
+//	})
+//	defer it.Close(lines)
+//	for lines.Next() {
+//		line := lines.Value()
+//		// line is in lexicographic order.
+//	}
+//	if err := lines.Err(); err != nil {
+//		// Handle error.
+//	}
+func SortExternal[T any](it Iterator[T], less func(a, b T) bool, codec Codec[T], opts SortExternalOptions[T]) Iterator[T] {
+	if opts.MaxChunkValues < 0 || opts.MaxChunkBytes < 0 || opts.MaxChunkBytes > 0 && opts.Size == nil {
+		panic("iterate: invalid chunk size")
+	}
+	if opts.MaxOpenFiles < 0 || opts.MaxOpenFiles == 1 {
+		panic("iterate: invalid number of open files")
+	}
+	if opts.MaxChunkValues == 0 && opts.MaxChunkBytes == 0 {
+		opts.MaxChunkValues = defaultMaxChunkValues
+	}
+	if opts.MaxOpenFiles == 0 {
+		opts.MaxOpenFiles = defaultMaxOpenFiles
+	}
+	if codec == nil {
+		codec = GobCodec[T]()
+	}
+	return &externalSorter[T]{
+		source: it,
+		less:   less,
+		codec:  codec,
+		opts:   opts,
+	}
+}
+
+type externalSorter[T any] struct {
+	source Iterator[T]
+	less   func(a, b T) bool
+	codec  Codec[T]
+	opts   SortExternalOptions[T]
+
+	started, stopped bool
+	// paths holds the names of the temporary files storing sorted chunks, in
+	// the order of the values they hold.
+	paths []string
+	// runs holds the iterators producing the sorted chunks being merged, and
+	// merged the iterator merging them.
+	runs   []Iterator[T]
+	merged Iterator[T]
+	value  T
+	err    error
+}
+
+// Next implements Iterator[T].Next.
+func (it *externalSorter[T]) Next() bool {
+	if it.stopped {
+		return false
+	}
+	if !it.started {
+		it.started = true
+		if err := it.sort(); err != nil {
+			return it.done(err)
+		}
+	}
+	if it.merged.Next() {
+		it.value = it.merged.Value()
+		return true
+	}
+	return it.done(it.merged.Err())
+}
+
+// sort consumes the source iterator, spilling sorted chunks to temporary
+// files, merges them in passes if there are too many files, and prepares the
+// merged iterator.
+func (it *externalSorter[T]) sort() error {
+	var chunk []T
+	var size int
+	for it.source.Next() {
+		v := it.source.Value()
+		chunk = append(chunk, v)
+		if it.opts.MaxChunkBytes > 0 {
+			size += it.opts.Size(v)
+		}
+		if !it.chunkFull(len(chunk), size) {
+			continue
+		}
+		it.sortChunk(chunk)
+		if err := it.write(FromSlice(chunk)); err != nil {
+			return err
+		}
+		clear(chunk)
+		chunk, size = chunk[:0], 0
+	}
+	if err := it.source.Err(); err != nil {
+		return err
+	}
+	for len(it.paths) > it.opts.MaxOpenFiles {
+		if err := it.mergePass(); err != nil {
+			return err
+		}
+	}
+	if err := it.open(it.paths); err != nil {
+		return err
+	}
+	// The last chunk is kept in memory.
+	it.sortChunk(chunk)
+	it.runs = append(it.runs, FromSlice(chunk))
+	it.merged = MergeSorted(it.less, it.runs...)
+	return nil
+}
+
+// mergePass merges groups of at most MaxOpenFiles consecutive sorted chunks
+// into new temporary files, preserving their order.
+func (it *externalSorter[T]) mergePass() error {
+	for remaining := len(it.paths); remaining > 0; {
+		n := min(remaining, it.opts.MaxOpenFiles)
+		if err := it.mergeFirst(n); err != nil {
+			return err
+		}
+		remaining -= n
+	}
+	return nil
+}
+
+// mergeFirst merges the first n sorted chunks into a new temporary file added
+// at the end of paths, and removes their files.
+func (it *externalSorter[T]) mergeFirst(n int) error {
+	if n == 1 {
+		// There is nothing to merge.
+		it.paths = append(it.paths[1:], it.paths[0])
+		return nil
+	}
+	if err := it.open(it.paths[:n]); err != nil {
+		return err
+	}
+	if err := it.write(MergeSorted(it.less, it.runs...)); err != nil {
+		return err
+	}
+	errs := []error{closeAll(it.runs)}
+	for _, path := range it.paths[:n] {
+		errs = append(errs, os.Remove(path))
+	}
+	it.runs, it.paths = nil, it.paths[n:]
+	return errors.Join(errs...)
+}
+
+// chunkFull reports whether a chunk with the given number of values and size
+// in bytes must be spilled to a temporary file.
+func (it *externalSorter[T]) chunkFull(n, size int) bool {
+	return it.opts.MaxChunkValues > 0 && n >= it.opts.MaxChunkValues ||
+		it.opts.MaxChunkBytes > 0 && size >= it.opts.MaxChunkBytes
+}
+
+// sortChunk sorts the given values in place, preserving the order of values
+// comparing equal.
+func (it *externalSorter[T]) sortChunk(chunk []T) {
+	sort.SliceStable(chunk, func(i, j int) bool {
+		return it.less(chunk[i], chunk[j])
+	})
+}
+
+// write stores the sorted values produced by the given iterator in a new
+// temporary file, added at the end of paths.
+func (it *externalSorter[T]) write(values Iterator[T]) error {
+	f, err := os.CreateTemp(it.opts.Dir, "iterate-sort-*")
+	if err != nil {
+		return err
+	}
+	it.paths = append(it.paths, f.Name())
+	w := bufio.NewWriter(f)
+	enc := it.codec.NewEncoder(w)
+	for values.Next() {
+		if err := enc.Encode(values.Value()); err != nil {
+			return errors.Join(err, f.Close())
+		}
+	}
+	if err := values.Err(); err != nil {
+		return errors.Join(err, f.Close())
+	}
+	if err := w.Flush(); err != nil {
+		return errors.Join(err, f.Close())
+	}
+	return f.Close()
+}
+
+// open adds iterators decoding the sorted chunks stored in the given files to
+// runs.
+func (it *externalSorter[T]) open(paths []string) error {
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		it.runs = append(it.runs, &decodeIterator[T]{
+			file: f,
+			dec:  it.codec.NewDecoder(bufio.NewReader(f)),
+		})
+	}
+	return nil
+}
+
+// done stops the iteration with the given error, and removes temporary files.
+func (it *externalSorter[T]) done(err error) bool {
+	it.value, it.stopped = *new(T), true
+	it.err = errors.Join(err, it.cleanup())
+	return false
+}
+
+// cleanup closes and removes the temporary files.
+func (it *externalSorter[T]) cleanup() error {
+	errs := []error{closeAll(it.runs)}
+	for _, path := range it.paths {
+		errs = append(errs, os.Remove(path))
+	}
+	it.runs, it.merged, it.paths = nil, nil, nil
+	return errors.Join(errs...)
+}
+
+// Value implements Iterator[T].Value by returning values in sorted order.
+func (it *externalSorter[T]) Value() T {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by propagating the error from the source
+// iterator, or any error occurred while storing values in temporary files.
+func (it *externalSorter[T]) Err() error {
+	return it.err
+}
+
+// Close implements IteratorCloser[T].Close by closing the source iterator and
+// removing temporary files.
+func (it *externalSorter[T]) Close() error {
+	it.stopped = true
+	return errors.Join(Close(it.source), it.cleanup())
+}
+
+// decodeIterator produces values decoded from a file.
+type decodeIterator[T any] struct {
+	file    *os.File
+	dec     Decoder[T]
+	value   T
+	stopped bool
+	err     error
+}
+
+// Next implements Iterator[T].Next.
+func (it *decodeIterator[T]) Next() bool {
+	if it.stopped {
+		return false
+	}
+	v, err := it.dec.Decode()
+	if err != nil {
+		if err != io.EOF {
+			it.err = err
+		}
+		it.value, it.stopped = *new(T), true
+		return false
+	}
+	it.value = v
+	return true
+}
+
+// Value implements Iterator[T].Value by returning decoded values.
+func (it *decodeIterator[T]) Value() T {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by propagating decoding errors.
+func (it *decodeIterator[T]) Err() error {
+	return it.err
+}
+
+// Close implements IteratorCloser[T].Close by closing the file.
+func (it *decodeIterator[T]) Close() error {
+	return it.file.Close()
+}
@@ -0,0 +1,262 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+type record struct {
+	Key, Seq int
+}
+
+func recordLess(a, b record) bool {
+	return a.Key < b.Key
+}
+
+func makeRecords(keys ...int) []record {
+	records := make([]record, len(keys))
+	for i, k := range keys {
+		records[i] = record{Key: k, Seq: i}
+	}
+	return records
+}
+
+// assertNoFiles checks that the given directory is empty.
+func assertNoFiles(t *testing.T, dir string) {
+	entries, err := os.ReadDir(dir)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.HasLen(entries, 0))
+}
+
+func TestSortExternal(t *testing.T) {
+	dir := t.TempDir()
+	records := makeRecords(5, 3, 8, 3, 1, 9, 5, 0, 3, 7)
+	iter := it.SortExternal(it.FromSlice(records), recordLess, nil, it.SortExternalOptions[record]{
+		MaxChunkValues: 3,
+		Dir:            dir,
+	})
+	qt.Assert(t, qt.IsTrue(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), record{Key: 0, Seq: 7}))
+
+	// Sorted chunks are stored in temporary files, except for the last one.
+	entries, err := os.ReadDir(dir)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.HasLen(entries, 3))
+
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	// Records with equal keys are in their original order.
+	qt.Assert(t, qt.DeepEquals(got, []record{
+		{Key: 1, Seq: 4},
+		{Key: 3, Seq: 1},
+		{Key: 3, Seq: 3},
+		{Key: 3, Seq: 8},
+		{Key: 5, Seq: 0},
+		{Key: 5, Seq: 6},
+		{Key: 7, Seq: 9},
+		{Key: 8, Seq: 2},
+		{Key: 9, Seq: 5},
+	}))
+	assertNoFiles(t, dir)
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), record{}))
+}
+
+func TestSortExternalInMemory(t *testing.T) {
+	dir := t.TempDir()
+	iter := it.SortExternal(it.FromSlice([]int{3, 1, 2}), intLess, nil, it.SortExternalOptions[int]{
+		Dir: dir,
+	})
+	qt.Assert(t, qt.IsTrue(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 1))
+	assertNoFiles(t, dir)
+
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{2, 3}))
+}
+
+func TestSortExternalEmpty(t *testing.T) {
+	got, err := it.ToSlice(it.SortExternal(it.FromSlice([]int{}), intLess, nil, it.SortExternalOptions[int]{}))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.IsNil(got))
+}
+
+func TestSortExternalSourceError(t *testing.T) {
+	dir := t.TempDir()
+	source := it.Chain[int](it.FromSlice([]int{4, 3, 2, 1}), &errorIterator[int]{v: 0})
+	got, err := it.ToSlice(it.SortExternal(source, intLess, nil, it.SortExternalOptions[int]{
+		MaxChunkValues: 2,
+		Dir:            dir,
+	}))
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.IsNil(got))
+	assertNoFiles(t, dir)
+}
+
+func TestSortExternalEncodeError(t *testing.T) {
+	dir := t.TempDir()
+	codec := &failingCodec{
+		Codec: it.GobCodec[int](),
+		err:   errors.New("bad wolf"),
+	}
+	got, err := it.ToSlice(it.SortExternal(it.FromSlice([]int{4, 3, 2, 1}), intLess, codec, it.SortExternalOptions[int]{
+		MaxChunkValues: 2,
+		Dir:            dir,
+	}))
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.IsNil(got))
+	assertNoFiles(t, dir)
+}
+
+func TestSortExternalClose(t *testing.T) {
+	dir := t.TempDir()
+	source := &closerIterator[int]{
+		Iterator: it.FromSlice([]int{4, 3, 2, 1, 0}),
+	}
+	iter := it.SortExternal[int](source, intLess, nil, it.SortExternalOptions[int]{
+		MaxChunkValues: 2,
+		Dir:            dir,
+	})
+	qt.Assert(t, qt.IsTrue(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+	qt.Assert(t, qt.IsNil(it.Close(iter)))
+	qt.Assert(t, qt.Equals(source.closed, 1))
+	assertNoFiles(t, dir)
+}
+
+func TestSortExternalInvalidOptions(t *testing.T) {
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.SortExternal(it.FromSlice([]int{}), intLess, nil, it.SortExternalOptions[int]{
+			MaxChunkValues: -1,
+		})
+	}, "iterate: invalid chunk size"))
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.SortExternal(it.FromSlice([]int{}), intLess, nil, it.SortExternalOptions[int]{
+			MaxChunkBytes: 10,
+		})
+	}, "iterate: invalid chunk size"))
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.SortExternal(it.FromSlice([]int{}), intLess, nil, it.SortExternalOptions[int]{
+			MaxOpenFiles: 1,
+		})
+	}, "iterate: invalid number of open files"))
+}
+
+func TestSortExternalMaxChunkBytes(t *testing.T) {
+	dir := t.TempDir()
+	words := []string{"these", "are", "the", "voyages", "of", "the", "starship", "enterprise"}
+	var sizes []int
+	iter := it.SortExternal(it.FromSlice(words), func(a, b string) bool {
+		return a < b
+	}, nil, it.SortExternalOptions[string]{
+		MaxChunkBytes: 10,
+		Size: func(v string) int {
+			sizes = append(sizes, len(v))
+			return len(v)
+		},
+		Dir: dir,
+	})
+	qt.Assert(t, qt.IsTrue(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), "are"))
+	qt.Assert(t, qt.HasLen(sizes, len(words)))
+
+	// Chunks are spilled as soon as they reach 10 bytes: "these", "are", "the",
+	// then "voyages", "of", "the", then "starship", "enterprise".
+	entries, err := os.ReadDir(dir)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.HasLen(entries, 3))
+
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []string{"enterprise", "of", "starship", "the", "the", "these", "voyages"}))
+	assertNoFiles(t, dir)
+}
+
+func TestSortExternalMaxOpenFiles(t *testing.T) {
+	dir := t.TempDir()
+	records := makeRecords(5, 3, 8, 3, 1, 9, 5, 0, 3, 7)
+	iter := it.SortExternal(it.FromSlice(records), recordLess, nil, it.SortExternalOptions[record]{
+		MaxChunkValues: 1,
+		MaxOpenFiles:   2,
+		Dir:            dir,
+	})
+	qt.Assert(t, qt.IsTrue(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), record{Key: 0, Seq: 7}))
+
+	// The 9 stored chunks are merged in multiple passes, so that only 2 files
+	// are left for the final merge: 9 files are merged into 5, then 3, then 2.
+	entries, err := os.ReadDir(dir)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.HasLen(entries, 2))
+
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	// Records with equal keys are still in their original order.
+	qt.Assert(t, qt.DeepEquals(got, []record{
+		{Key: 1, Seq: 4},
+		{Key: 3, Seq: 1},
+		{Key: 3, Seq: 3},
+		{Key: 3, Seq: 8},
+		{Key: 5, Seq: 0},
+		{Key: 5, Seq: 6},
+		{Key: 7, Seq: 9},
+		{Key: 8, Seq: 2},
+		{Key: 9, Seq: 5},
+	}))
+	assertNoFiles(t, dir)
+}
+
+func TestSortExternalMaxOpenFilesEncodeError(t *testing.T) {
+	dir := t.TempDir()
+	codec := &failingCodec{
+		Codec: it.GobCodec[int](),
+		err:   errors.New("bad wolf"),
+	}
+	// Chunks of one value are stored successfully, but merging them fails.
+	got, err := it.ToSlice(it.SortExternal(it.FromSlice([]int{4, 3, 2, 1}), intLess, codec, it.SortExternalOptions[int]{
+		MaxChunkValues: 1,
+		MaxOpenFiles:   2,
+		Dir:            dir,
+	}))
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.IsNil(got))
+	assertNoFiles(t, dir)
+}
+
+// failingCodec is a codec whose encoders fail after encoding the first value.
+type failingCodec struct {
+	it.Codec[int]
+	err error
+}
+
+func (c *failingCodec) NewEncoder(w io.Writer) it.Encoder[int] {
+	return &failingEncoder{
+		Encoder: c.Codec.NewEncoder(w),
+		err:     c.err,
+	}
+}
+
+type failingEncoder struct {
+	it.Encoder[int]
+	err     error
+	encoded bool
+}
+
+func (e *failingEncoder) Encode(v int) error {
+	if e.encoded {
+		return e.err
+	}
+	e.encoded = true
+	return e.Encoder.Encode(v)
+}
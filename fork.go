@@ -0,0 +1,166 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+import "sync"
+
+// Fork returns n independent iterators producing the values from the given
+// iterator. The source iterator is consumed only once, and values are buffered
+// until all the returned iterators retrieved them, so memory usage depends on
+// how far the slowest iterator is behind the fastest one. Errors from the
+// source iterator are returned by all the iterators. When closed, the returned
+// iterators close the source iterator only once all of them are closed. It
+// panics if n is less than 1.
+//
+// For instance:
+//
+//	forks := it.Fork(it.Lines(r), 2)
+//	lengths := it.Map(forks[0], func(line string) int {
+//		return len(line)
+//	})
+//	words := it.FlatMap(forks[1], func(line string) it.Iterator[string] {
+//		return it.FromSlice(strings.Fields(line))
+//	})
+//
+// The returned iterators are not safe for concurrent use: see ForkConcurrent
+// for consuming them from different goroutines.
+func Fork[T any](it Iterator[T], n int) []Iterator[T] {
+	return newForker(it, n, noopLocker{})
+}
+
+// ForkConcurrent is like Fork, but the returned iterators can be safely
+// consumed from different goroutines.
+func ForkConcurrent[T any](it Iterator[T], n int) []Iterator[T] {
+	return newForker(it, n, &sync.Mutex{})
+}
+
+func newForker[T any](it Iterator[T], n int, mu sync.Locker) []Iterator[T] {
+	if n < 1 {
+		panic("iterate: invalid number of forks")
+	}
+	f := &forker[T]{
+		source:    it,
+		mu:        mu,
+		positions: make([]int, n),
+		closed:    make([]bool, n),
+	}
+	its := make([]Iterator[T], n)
+	for i := range its {
+		its[i] = &forkIterator[T]{
+			f:  f,
+			id: i,
+		}
+	}
+	return its
+}
+
+type forker[T any] struct {
+	source Iterator[T]
+
+	// mu protects all the fields below.
+	mu sync.Locker
+	// buf holds the values not yet retrieved by all the iterators, starting
+	// from the absolute index base.
+	buf  []T
+	base int
+	// positions holds the absolute index of the next value for each iterator.
+	positions []int
+	closed    []bool
+	done      bool
+}
+
+// next returns the next value for the iterator with the given id.
+func (f *forker[T]) next(id int) (T, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pos := f.positions[id]
+	if pos == f.base+len(f.buf) {
+		if f.done || !f.source.Next() {
+			f.done = true
+			return *new(T), false
+		}
+		f.buf = append(f.buf, f.source.Value())
+	}
+	v := f.buf[pos-f.base]
+	f.positions[id]++
+	f.release()
+	return v, true
+}
+
+// release discards the buffered values already retrieved by all the open
+// iterators.
+func (f *forker[T]) release() {
+	lowest := f.base + len(f.buf)
+	for id, pos := range f.positions {
+		if !f.closed[id] {
+			lowest = min(lowest, pos)
+		}
+	}
+	if n := lowest - f.base; n > 0 {
+		clear(f.buf[:n])
+		f.buf, f.base = f.buf[n:], lowest
+	}
+}
+
+// err returns the error from the source iterator.
+func (f *forker[T]) err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.source.Err()
+}
+
+// close marks the iterator with the given id as closed. The source iterator is
+// closed when all the iterators are closed.
+func (f *forker[T]) close(id int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed[id] {
+		return nil
+	}
+	f.closed[id] = true
+	f.release()
+	for _, closed := range f.closed {
+		if !closed {
+			return nil
+		}
+	}
+	return Close(f.source)
+}
+
+// forkIterator is the iterator returned for each fork.
+type forkIterator[T any] struct {
+	f     *forker[T]
+	id    int
+	value T
+}
+
+// Next implements Iterator[T].Next.
+func (it *forkIterator[T]) Next() bool {
+	var ok bool
+	it.value, ok = it.f.next(it.id)
+	return ok
+}
+
+// Value implements Iterator[T].Value by returning values from the source
+// iterator.
+func (it *forkIterator[T]) Value() T {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by propagating the error from the source
+// iterator.
+func (it *forkIterator[T]) Err() error {
+	return it.f.err()
+}
+
+// Close implements IteratorCloser[T].Close. The source iterator is closed when
+// all the other forks are closed as well.
+func (it *forkIterator[T]) Close() error {
+	return it.f.close(it.id)
+}
+
+// noopLocker is a sync.Locker that does nothing.
+type noopLocker struct{}
+
+func (noopLocker) Lock()   {}
+func (noopLocker) Unlock() {}
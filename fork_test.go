@@ -0,0 +1,100 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestFork(t *testing.T) {
+	var pulled int
+	source := it.Tee(it.Count(0, 5, 1), func(v int) {
+		pulled++
+	})
+	forks := it.Fork(source, 3)
+	qt.Assert(t, qt.HasLen(forks, 3))
+
+	got, err := it.ToSlice(it.Limit(forks[0], 3))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{0, 1, 2}))
+	// The source is consumed lazily.
+	qt.Assert(t, qt.Equals(pulled, 4))
+
+	qt.Assert(t, qt.IsTrue(forks[1].Next()))
+	qt.Assert(t, qt.Equals(forks[1].Value(), 0))
+
+	got, err = it.ToSlice(forks[2])
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{0, 1, 2, 3, 4}))
+
+	got, err = it.ToSlice(forks[1])
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 2, 3, 4}))
+
+	// The source is consumed only once.
+	qt.Assert(t, qt.Equals(pulled, 5))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(forks[1].Next()))
+	qt.Assert(t, qt.Equals(forks[1].Value(), 0))
+}
+
+func TestForkError(t *testing.T) {
+	forks := it.Fork[string](&errorIterator[string]{
+		v: "engage",
+	}, 2)
+	for _, fork := range forks {
+		got, err := it.ToSlice(fork)
+		qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+		qt.Assert(t, qt.DeepEquals(got, []string{"engage"}))
+	}
+}
+
+func TestForkClose(t *testing.T) {
+	source := &closerIterator[int]{
+		Iterator: it.Count(0, 5, 1),
+	}
+	forks := it.Fork[int](source, 2)
+
+	qt.Assert(t, qt.IsNil(it.Close(forks[0])))
+	qt.Assert(t, qt.IsNil(it.Close(forks[0])))
+	qt.Assert(t, qt.Equals(source.closed, 0))
+
+	// The remaining fork can still be consumed.
+	got, err := it.ToSlice(forks[1])
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{0, 1, 2, 3, 4}))
+
+	qt.Assert(t, qt.IsNil(it.Close(forks[1])))
+	qt.Assert(t, qt.Equals(source.closed, 1))
+}
+
+func TestForkInvalid(t *testing.T) {
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.Fork(it.Count(0, 5, 1), 0)
+	}, "iterate: invalid number of forks"))
+}
+
+func TestForkConcurrent(t *testing.T) {
+	forks := it.ForkConcurrent(it.Count(0, 1000, 1), 4)
+	sums := make([]int, len(forks))
+	errs := make([]error, len(forks))
+	var wg sync.WaitGroup
+	for i, fork := range forks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sums[i], errs[i] = it.Sum(fork)
+		}()
+	}
+	wg.Wait()
+	for i := range forks {
+		qt.Assert(t, qt.IsNil(errs[i]))
+		qt.Assert(t, qt.Equals(sums[i], 499500))
+	}
+}
@@ -2,6 +2,8 @@
 
 package iterate
 
+import "errors"
+
 // Filter returns an iterator producing values from the given interator, for
 // which predicate(v) is true.
 func Filter[T any](it Iterator[T], predicate func(v T) bool) Iterator[T] {
@@ -27,6 +29,11 @@ func (it *filter[T]) Next() bool {
 	return false
 }
 
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *filter[T]) Close() error {
+	return Close(it.Iterator)
+}
+
 // Map returns an iterator that computes the given function using values from
 // the given iterator.
 func Map[S, D any](source Iterator[S], f func(v S) D) Iterator[D] {
@@ -46,6 +53,11 @@ func (it *mapper[S, D]) Value() D {
 	return it.f(it.Iterator.Value())
 }
 
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *mapper[S, D]) Close() error {
+	return Close(it.Iterator)
+}
+
 // Reduce applies f cumulatively to the values of the given iterator, from left
 // to right, so as to reduce the iterable to a single value. The first argument
 // is the accumulated value and the second argument is the value from the
@@ -65,6 +77,77 @@ func Reduce[T, A any](it Iterator[T], f func(a A, v T) A, initial A) (A, error)
 	return initial, it.Err()
 }
 
+// Scan returns an iterator producing the values accumulated by applying f
+// cumulatively to the values of the given iterator, from left to right. The
+// first argument is the accumulated value and the second argument is the value
+// from the iterator. Scan is the lazy counterpart of Reduce: the last value
+// produced is the result of reducing the iterator.
+//
+// For instance, for calculating running totals:
+//
+//	totals := it.Scan(it.FromSlice([]int{1, 2, 3, 4}), func(a, v int) int {
+//		return a + v
+//	}, 0)
+//	for totals.Next() {
+//		v := totals.Value()
+//		// v is 1, then 3, then 6, then 10.
+//	}
+func Scan[T, A any](it Iterator[T], f func(a A, v T) A, initial A) Iterator[A] {
+	return &scanner[T, A]{
+		source: it,
+		f:      f,
+		acc:    initial,
+	}
+}
+
+type scanner[T, A any] struct {
+	source  Iterator[T]
+	f       func(a A, v T) A
+	acc     A
+	stopped bool
+}
+
+// Next implements Iterator[T].Next by accumulating the next value.
+func (it *scanner[T, A]) Next() bool {
+	if it.stopped || !it.source.Next() {
+		it.stopped = true
+		return false
+	}
+	it.acc = it.f(it.acc, it.source.Value())
+	return true
+}
+
+// Value implements Iterator[T].Value by returning the accumulated value.
+func (it *scanner[T, A]) Value() A {
+	if it.stopped {
+		return *new(A)
+	}
+	return it.acc
+}
+
+// Err implements Iterator[T].Err by propagating the error from the source
+// iterator.
+func (it *scanner[T, A]) Err() error {
+	return it.source.Err()
+}
+
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *scanner[T, A]) Close() error {
+	return Close(it.source)
+}
+
+// ScanWithInitial is like Scan, but the initial value is produced first.
+//
+// For instance, for calculating prefix sums:
+//
+//	sums := it.ScanWithInitial(it.FromSlice([]int{1, 2, 3}), func(a, v int) int {
+//		return a + v
+//	}, 0)
+//	// sums produces 0, then 1, then 3, then 6.
+func ScanWithInitial[T, A any](it Iterator[T], f func(a A, v T) A, initial A) Iterator[A] {
+	return Chain(FromSlice([]A{initial}), Scan(it, f, initial))
+}
+
 // Chain returns an iterator producing values from the concatenation of all the
 // given iterators. The iteration is stopped when all iterators are consumed or
 // when any of them has an error.
@@ -72,12 +155,14 @@ func Chain[T any](base Iterator[T], others ...Iterator[T]) Iterator[T] {
 	return &chain[T]{
 		Iterator: base,
 		others:   others,
+		all:      append([]Iterator[T]{base}, others...),
 	}
 }
 
 type chain[T any] struct {
 	Iterator[T]
 	others []Iterator[T]
+	all    []Iterator[T]
 }
 
 // Next implements Iterator[T].Next by producing values until all iterators
@@ -93,6 +178,99 @@ func (it *chain[T]) Next() bool {
 	return it.Next()
 }
 
+// Close implements IteratorCloser[T].Close by closing all the iterators,
+// including the ones already consumed.
+func (it *chain[T]) Close() error {
+	return closeAll(it.all)
+}
+
+// Flatten returns an iterator producing values from the concatenation of all
+// the iterators produced by the given iterator. As with Chain, the iteration is
+// stopped when all iterators are consumed or when any of them, including the
+// given one, has an error. Inner iterators are closed as soon as they are
+// consumed.
+func Flatten[T any](its Iterator[Iterator[T]]) Iterator[T] {
+	return &flattener[T]{
+		source: its,
+	}
+}
+
+type flattener[T any] struct {
+	source  Iterator[Iterator[T]]
+	current Iterator[T]
+	err     error
+}
+
+// Next implements Iterator[T].Next by producing values until all iterators
+// are consumed.
+func (it *flattener[T]) Next() bool {
+	for it.err == nil {
+		if it.current != nil {
+			if it.current.Next() {
+				return true
+			}
+			it.err = it.current.Err()
+			if err := Close(it.current); it.err == nil {
+				it.err = err
+			}
+			it.current = nil
+			continue
+		}
+		if !it.source.Next() {
+			return false
+		}
+		it.current = it.source.Value()
+	}
+	return false
+}
+
+// Value implements Iterator[T].Value by returning values from the current
+// inner iterator.
+func (it *flattener[T]) Value() T {
+	if it.current == nil {
+		return *new(T)
+	}
+	return it.current.Value()
+}
+
+// Err implements Iterator[T].Err by propagating the error from the inner
+// iterators or from the source iterator.
+func (it *flattener[T]) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.source.Err()
+}
+
+// Close implements IteratorCloser[T].Close by closing the current inner
+// iterator and the source iterator.
+func (it *flattener[T]) Close() error {
+	var err error
+	if it.current != nil {
+		err = Close(it.current)
+		it.current = nil
+	}
+	return errors.Join(err, Close(it.source))
+}
+
+// FlattenSlices returns an iterator producing values from the concatenation of
+// all the slices produced by the given iterator.
+func FlattenSlices[T any](it Iterator[[]T]) Iterator[T] {
+	return Flatten(Map(it, FromSlice[T]))
+}
+
+// FlatMap returns an iterator producing values from the concatenation of the
+// iterators returned by calling f with values from the given iterator.
+//
+// For instance:
+//
+//	words := it.FlatMap(it.Lines(r), func(line string) it.Iterator[string] {
+//		return it.FromSlice(strings.Fields(line))
+//	})
+func FlatMap[S, D any](source Iterator[S], f func(v S) Iterator[D]) Iterator[D] {
+	return Flatten(Map(source, f))
+}
+
 // Repeat returns an iterator repeating values from the given iterator
 // endlessly.
 func Repeat[T any](it Iterator[T]) Iterator[T] {
@@ -137,6 +315,11 @@ func (it *repeater[T]) Err() error {
 	return it.source.Err()
 }
 
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *repeater[T]) Close() error {
+	return Close(it.source)
+}
+
 // Tee returns an iterator that causes the given function to be called each time
 // a value is produced.
 func Tee[T any](it Iterator[T], f func(v T)) Iterator[T] {
@@ -114,6 +114,70 @@ func TestReduceError(t *testing.T) {
 	qt.Assert(t, qt.DeepEquals(got, 15))
 }
 
+func TestScan(t *testing.T) {
+	iter := it.Scan(it.FromSlice([]int{1, 2, 3, 4}), func(a, v int) int {
+		return a + v
+	}, 0)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 3, 6, 10}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+}
+
+func TestScanDifferentTypes(t *testing.T) {
+	iter := it.FromSlice([]string{"these", "are", "the", "voyages"})
+	maxLengths := it.Scan(iter, func(a int, v string) int {
+		return max(a, len(v))
+	}, 0)
+	// Scan composes with other iterators.
+	got, err := it.ToSlice(it.Zip(maxLengths, it.Count(0, 10, 1)))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []it.KeyValue[int, int]{
+		{Key: 5, Value: 0},
+		{Key: 5, Value: 1},
+		{Key: 5, Value: 2},
+		{Key: 7, Value: 3},
+	}))
+}
+
+func TestScanSkipValue(t *testing.T) {
+	calls := 0
+	iter := it.Scan(it.Count(1, 10, 1), func(a, v int) int {
+		calls++
+		return a * v
+	}, 1)
+	iter.Next()
+	iter.Next()
+	iter.Next()
+	qt.Assert(t, qt.Equals(iter.Value(), 6))
+	qt.Assert(t, qt.Equals(iter.Value(), 6))
+	qt.Assert(t, qt.Equals(calls, 3))
+	qt.Assert(t, qt.IsNil(iter.Err()))
+}
+
+func TestScanError(t *testing.T) {
+	iter := it.Chain[int](it.Count(1, 5, 1), &errorIterator[int]{v: 5})
+	got, err := it.ToSlice(it.Scan(iter, func(a, v int) int {
+		return a + v
+	}, 0))
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 3, 6, 10, 15}))
+}
+
+func TestScanWithInitial(t *testing.T) {
+	iter := it.ScanWithInitial(it.FromSlice([]int{1, 2, 3}), func(a, v int) int {
+		return a + v
+	}, 0)
+	got, err := it.ToSlice(it.TakeWhile(iter, func(idx, v int) bool {
+		return v < 5
+	}))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{0, 1, 3}))
+}
+
 func TestChain(t *testing.T) {
 	iter := it.Chain(it.FromSlice([]string{"1", "2"}), it.FromSlice([]string{"3", "4"}))
 	got, err := it.ToSlice(iter)
@@ -132,6 +196,87 @@ func TestChainError(t *testing.T) {
 	qt.Assert(t, qt.DeepEquals(got, []int{1, 2, 3, 4, 5}))
 }
 
+func TestFlatten(t *testing.T) {
+	iter := it.Flatten(it.FromSlice([]it.Iterator[string]{
+		it.FromSlice([]string{"1", "2"}),
+		it.FromSlice([]string{}),
+		it.FromSlice([]string{"3"}),
+	}))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []string{"1", "2", "3"}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), ""))
+}
+
+func TestFlattenInnerError(t *testing.T) {
+	iter := it.Flatten(it.FromSlice([]it.Iterator[int]{
+		it.Count(1, 3, 1),
+		&errorIterator[int]{v: 3},
+		it.Count(4, 6, 1),
+	}))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 2, 3}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+}
+
+func TestFlattenOuterError(t *testing.T) {
+	iter := it.Flatten[int](&errorIterator[it.Iterator[int]]{
+		v: it.Count(1, 3, 1),
+	})
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 2}))
+}
+
+func TestFlattenClose(t *testing.T) {
+	inner1 := &closerIterator[int]{
+		Iterator: it.Count(1, 3, 1),
+	}
+	inner2 := &closerIterator[int]{
+		Iterator: it.Count(3, 5, 1),
+	}
+	outer := &closerIterator[it.Iterator[int]]{
+		Iterator: it.FromSlice([]it.Iterator[int]{inner1, inner2}),
+	}
+	iter := it.Flatten[int](outer)
+	got, err := it.ToSlice(it.Limit(iter, 3))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 2, 3}))
+
+	// Consumed inner iterators are closed.
+	qt.Assert(t, qt.Equals(inner1.closed, 1))
+	qt.Assert(t, qt.Equals(inner2.closed, 0))
+
+	qt.Assert(t, qt.IsNil(it.Close(iter)))
+	qt.Assert(t, qt.Equals(inner1.closed, 1))
+	qt.Assert(t, qt.Equals(inner2.closed, 1))
+	qt.Assert(t, qt.Equals(outer.closed, 1))
+}
+
+func TestFlattenSlices(t *testing.T) {
+	iter := it.FlattenSlices(it.FromSlice([][]int{{1, 2}, nil, {3, 4, 5}}))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 2, 3, 4, 5}))
+}
+
+func TestFlatMap(t *testing.T) {
+	lines := it.Lines(strings.NewReader("these are\nthe voyages"))
+	words := it.FlatMap(lines, func(line string) it.Iterator[string] {
+		return it.FromSlice(strings.Fields(line))
+	})
+	got, err := it.ToSlice(words)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []string{"these", "are", "the", "voyages"}))
+}
+
 func TestRepeat(t *testing.T) {
 	iter := it.Limit(it.Repeat(it.Count(3, 0, -1)), 9)
 	got, err := it.ToSlice(iter)
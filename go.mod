@@ -1,6 +1,6 @@
 module github.com/frankban/iterate
 
-go 1.18
+go 1.23
 
 require (
 	github.com/go-quicktest/qt v0.1.0
@@ -2,6 +2,12 @@
 
 package iterate
 
+import "errors"
+
+// ErrBufferFull is returned by iterators when the number of values buffered in
+// memory exceeds the configured limit.
+var ErrBufferFull = errors.New("iterate: buffer full")
+
 // GroupBy returns an iterator returning key/value pairs in which the key is the
 // key used for grouping elements using the given function, and the value is an
 // iterator of values with that key.
@@ -19,10 +25,34 @@ package iterate
 //         // then (5, Iterator("hello")), then (3, Iterator("the", "are"))
 //     }
 //
+// Group iterators are only valid until the groups iterator is advanced: values
+// not yet consumed are then discarded, so that memory usage does not grow when
+// groups are skipped. See GroupByWithOptions for preserving skipped groups.
 func GroupBy[T any, K comparable](it Iterator[T], f func(v T) K) Iterator[KeyValue[K, Iterator[T]]] {
+	return GroupByWithOptions(it, f, GroupByOptions{})
+}
+
+// GroupByOptions holds options for GroupByWithOptions.
+type GroupByOptions struct {
+	// KeepSkipped, if true, preserves the values of all groups when the groups
+	// iterator is advanced, so that group iterators can be consumed in any
+	// order. Values are held in memory until retrieved by group iterators, or
+	// until group iterators are closed.
+	KeepSkipped bool
+
+	// MaxPending, if not zero, is the maximum number of values held in memory
+	// waiting to be retrieved by group iterators. When the limit is exceeded,
+	// the iteration is stopped and ErrBufferFull is returned.
+	MaxPending int
+}
+
+// GroupByWithOptions is like GroupBy, but the given options are used for
+// controlling how values are held in memory.
+func GroupByWithOptions[T any, K comparable](it Iterator[T], f func(v T) K, opts GroupByOptions) Iterator[KeyValue[K, Iterator[T]]] {
 	return &grouper[T, K]{
 		source:        it,
 		f:             f,
+		opts:          opts,
 		pendingValues: make(map[int][]T),
 	}
 }
@@ -30,50 +60,93 @@ func GroupBy[T any, K comparable](it Iterator[T], f func(v T) K) Iterator[KeyVal
 type grouper[T any, K comparable] struct {
 	source        Iterator[T]
 	f             func(v T) K
-	id            int
+	opts          GroupByOptions
+	current       int
 	key           K
-	pendingValues map[int][]T
 	iter          Iterator[T]
+	id            int
+	lastKey       K
+	lastIter      Iterator[T]
+	pendingValues map[int][]T
+	pending       int
+	err           error
 }
 
 // Next implements Iterator[T].Next by iterating over groups.
 func (it *grouper[T, K]) Next() bool {
-	if !it.next() {
-		it.iter = nil
-		it.key = *new(K)
-		return false
+	if !it.opts.KeepSkipped {
+		// The current group is being skipped.
+		it.discard(it.current)
 	}
-	if it.iter != nil {
-		return true
+	// Advance until a new group is found. The new group could have been
+	// already found by the current group iterator.
+	for it.id == it.current {
+		if !it.next(true) {
+			it.iter = nil
+			it.key = *new(K)
+			return false
+		}
 	}
-	return it.Next()
+	it.current, it.key, it.iter = it.id, it.lastKey, it.lastIter
+	return true
 }
 
-func (it *grouper[T, K]) next() bool {
-	if !it.source.Next() {
+// next advances the source iterator, storing the produced value for the
+// corresponding group. When called while advancing the groups iterator, values
+// for the group being skipped are discarded unless skipped groups must be kept.
+func (it *grouper[T, K]) next(skipping bool) bool {
+	if it.err != nil || !it.source.Next() {
 		// The iteration is done.
 		return false
 	}
 
 	val := it.source.Value()
 	key := it.f(val)
-	if it.id == 0 || key != it.key {
+	if it.id == 0 || key != it.lastKey {
 		// We either are at the beginning of the iteration, or the key just
-		// changed. Store a new iterator to be returned on the next call to
-		// Value.
+		// changed. Store a new iterator to be returned when the groups
+		// iterator is advanced.
 		it.id++
-		it.iter = &groupKeyIterator[T, K]{
+		it.lastIter = &groupKeyIterator[T, K]{
 			source: it,
 			id:     it.id,
 		}
-		it.key = key
+		it.lastKey = key
+	} else if skipping && !it.opts.KeepSkipped {
+		return true
 	}
 
 	// Store the produced value waiting for group iterators to retrieve it.
 	it.pendingValues[it.id] = append(it.pendingValues[it.id], val)
+	it.pending++
+	if it.opts.MaxPending > 0 && it.pending > it.opts.MaxPending {
+		it.err = ErrBufferFull
+		return false
+	}
 	return true
 }
 
+// pop returns the next pending value for the group with the given id.
+func (it *grouper[T, K]) pop(id int) (T, bool) {
+	vs := it.pendingValues[id]
+	if len(vs) == 0 {
+		return *new(T), false
+	}
+	if len(vs) == 1 {
+		delete(it.pendingValues, id)
+	} else {
+		it.pendingValues[id] = vs[1:]
+	}
+	it.pending--
+	return vs[0], true
+}
+
+// discard releases the pending values for the group with the given id.
+func (it *grouper[T, K]) discard(id int) {
+	it.pending -= len(it.pendingValues[id])
+	delete(it.pendingValues, id)
+}
+
 // Value implements Iterator[T].Value by iterating over groups.
 func (it *grouper[T, K]) Value() KeyValue[K, Iterator[T]] {
 	return KeyValue[K, Iterator[T]]{
@@ -83,11 +156,19 @@ func (it *grouper[T, K]) Value() KeyValue[K, Iterator[T]] {
 }
 
 // Err implements Iterator[T].Err by propagating the error from the source
-// iterator.
+// iterator, or by returning ErrBufferFull if too many values are pending.
 func (it *grouper[T, K]) Err() error {
+	if it.err != nil {
+		return it.err
+	}
 	return it.source.Err()
 }
 
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *grouper[T, K]) Close() error {
+	return Close(it.source)
+}
+
 // groupKeyIterator is the iterator returned for generating values for a
 // specific group key.
 type groupKeyIterator[T any, K comparable] struct {
@@ -100,14 +181,14 @@ type groupKeyIterator[T any, K comparable] struct {
 func (it *groupKeyIterator[T, K]) Next() bool {
 	for {
 		// Check whether there are pending values already.
-		if len(it.source.pendingValues[it.id]) > 0 {
-			it.value, it.source.pendingValues[it.id] = it.source.pendingValues[it.id][0], it.source.pendingValues[it.id][1:]
+		if v, ok := it.source.pop(it.id); ok {
+			it.value = v
 			return true
 		}
 
 		// Check whether the grouper is still iterating over this id, in which case we
 		// can progress the iteration further and retry.
-		if it.source.id == it.id && it.source.next() {
+		if it.source.id == it.id && it.source.next(false) {
 			continue
 		}
 
@@ -127,3 +208,168 @@ func (it *groupKeyIterator[T, K]) Value() T {
 func (it *groupKeyIterator[T, K]) Err() error {
 	return it.source.Err()
 }
+
+// Close implements IteratorCloser[T].Close by discarding the values pending for
+// this group. The source iterator is shared with other groups, and it is only
+// closed when closing the groups iterator.
+func (it *groupKeyIterator[T, K]) Close() error {
+	it.source.discard(it.id)
+	return nil
+}
+
+// GroupByAll returns an iterator returning key/value pairs in which the key is
+// the key used for grouping elements using the given function, and the value is
+// the slice of all the values with that key. Unlike GroupBy, values with the
+// same key are grouped even if they are not consecutive. Groups are returned
+// in the order in which their keys are first seen.
+//
+// The given iterator is fully consumed on the first call to Next, and all
+// values are held in memory: see GroupReduce for computing per-key aggregates
+// without holding all the values. If the iterator returns an error, the groups
+// collected so far are produced before the error is reported by Err.
+//
+// For instance:
+//
+//	words := it.FromSlice([]string{"a", "be", "it", "hello", "no", "the", "are"})
+//	// Group words by length.
+//	groups := it.GroupByAll(words, func(v string) int {
+//		return len(v)
+//	})
+//	for groups.Next() {
+//		kv := groups.Value()
+//		// kv is (1, ["a"]), then (2, ["be", "it", "no"]),
+//		// then (5, ["hello"]), then (3, ["the", "are"])
+//	}
+func GroupByAll[T any, K comparable](it Iterator[T], f func(v T) K) Iterator[KeyValue[K, []T]] {
+	return GroupReduce(it, f, func(a []T, v T) []T {
+		return append(a, v)
+	}, newNilSlice[T])
+}
+
+// GroupInto consumes the given iterator and returns a map of values grouped by
+// the key computed with the given function. An error is returned if the
+// iterator returns an error, in which case the returned map includes the values
+// already consumed.
+func GroupInto[T any, K comparable](it Iterator[T], f func(v T) K) (map[K][]T, error) {
+	_, groups, err := reduceGroups(it, f, func(a []T, v T) []T {
+		return append(a, v)
+	}, newNilSlice[T])
+	return groups, err
+}
+
+// newNilSlice returns a nil slice, used as initial accumulated value when
+// grouping values into slices.
+func newNilSlice[T any]() []T {
+	return nil
+}
+
+// GroupReduce returns an iterator returning key/value pairs in which the key is
+// the key used for grouping elements using the given function, and the value is
+// the result of applying reduce cumulatively to the values with that key, as
+// in Reduce. The initial function is called to create the starting accumulated
+// value for each group, so that groups never share mutable values such as maps
+// or slices. Groups are returned in the order in which their keys are first
+// seen.
+//
+// The given iterator is fully consumed on the first call to Next, but only the
+// accumulated value for each key is held in memory. If the iterator returns an
+// error, the groups accumulated so far are produced before the error is
+// reported by Err, as in GroupInto.
+//
+// For instance, for counting words by length:
+//
+//	words := it.FromSlice([]string{"a", "be", "it", "hello", "no", "the", "are"})
+//	counts := it.GroupReduce(words, func(v string) int {
+//		return len(v)
+//	}, func(a int, v string) int {
+//		return a + 1
+//	}, func() int {
+//		return 0
+//	})
+//	for counts.Next() {
+//		kv := counts.Value()
+//		// kv is (1, 1), then (2, 3), then (5, 1), then (3, 2)
+//	}
+func GroupReduce[T any, K comparable, A any](it Iterator[T], f func(v T) K, reduce func(a A, v T) A, initial func() A) Iterator[KeyValue[K, A]] {
+	return &groupReducer[T, K, A]{
+		source:  it,
+		f:       f,
+		reduce:  reduce,
+		initial: initial,
+		idx:     -1,
+	}
+}
+
+type groupReducer[T any, K comparable, A any] struct {
+	source  Iterator[T]
+	f       func(v T) K
+	reduce  func(a A, v T) A
+	initial func() A
+
+	started bool
+	keys    []K
+	groups  map[K]A
+	idx     int
+	err     error
+}
+
+// Next implements Iterator[T].Next by iterating over groups.
+func (it *groupReducer[T, K, A]) Next() bool {
+	if !it.started {
+		it.started = true
+		it.keys, it.groups, it.err = reduceGroups(it.source, it.f, it.reduce, it.initial)
+	}
+	if it.idx == len(it.keys) {
+		return false
+	}
+	it.idx++
+	if it.idx == len(it.keys) {
+		// Release memory.
+		it.keys, it.groups, it.idx = nil, nil, 0
+		return false
+	}
+	return true
+}
+
+// Value implements Iterator[T].Value by returning groups and their
+// accumulated values.
+func (it *groupReducer[T, K, A]) Value() KeyValue[K, A] {
+	if it.idx < 0 || it.idx >= len(it.keys) {
+		return KeyValue[K, A]{}
+	}
+	key := it.keys[it.idx]
+	return KeyValue[K, A]{
+		Key:   key,
+		Value: it.groups[key],
+	}
+}
+
+// Err implements Iterator[T].Err by propagating the error from the source
+// iterator.
+func (it *groupReducer[T, K, A]) Err() error {
+	return it.err
+}
+
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *groupReducer[T, K, A]) Close() error {
+	return Close(it.source)
+}
+
+// reduceGroups consumes the given iterator, grouping values by key and
+// reducing them with the given function. It returns the keys in the order in
+// which they are first seen, and the accumulated values by key.
+func reduceGroups[T any, K comparable, A any](it Iterator[T], f func(v T) K, reduce func(a A, v T) A, initial func() A) ([]K, map[K]A, error) {
+	var keys []K
+	groups := make(map[K]A)
+	for it.Next() {
+		v := it.Value()
+		key := f(v)
+		a, ok := groups[key]
+		if !ok {
+			keys = append(keys, key)
+			a = initial()
+		}
+		groups[key] = reduce(a, v)
+	}
+	return keys, groups, it.Err()
+}
@@ -10,7 +10,7 @@ import (
 	it "github.com/frankban/iterate"
 )
 
-func TestGroupBy(t *testing.T) {
+func TestGroupByKeepSkipped(t *testing.T) {
 	// Group words by length.
 	words := it.FromSlice([]string{
 		"a",
@@ -20,8 +20,10 @@ func TestGroupBy(t *testing.T) {
 		"be", "it",
 		"again",
 	})
-	groups := it.GroupBy(words, func(v string) int {
+	groups := it.GroupByWithOptions(words, func(v string) int {
 		return len(v)
+	}, it.GroupByOptions{
+		KeepSkipped: true,
 	})
 
 	// Consume groups.
@@ -115,3 +117,237 @@ func TestGroupBy(t *testing.T) {
 	qt.Assert(t, qt.IsNil(group5.Err()))
 	qt.Assert(t, qt.IsNil(group4.Err()))
 }
+
+func TestGroupBy(t *testing.T) {
+	// Group words by length.
+	words := it.FromSlice([]string{
+		"a",
+		"be", "it", "no",
+		"hello",
+		"the", "are",
+		"be", "it",
+		"again",
+	})
+	groups := it.GroupBy(words, func(v string) int {
+		return len(v)
+	})
+
+	// Skip the first group.
+	qt.Assert(t, qt.IsTrue(groups.Next()))
+	k, group1 := groups.Value().Split()
+	qt.Assert(t, qt.Equals(k, 1))
+
+	// Partially consume the second group.
+	qt.Assert(t, qt.IsTrue(groups.Next()))
+	k, group2 := groups.Value().Split()
+	qt.Assert(t, qt.Equals(k, 2))
+	qt.Assert(t, qt.IsTrue(group2.Next()))
+	qt.Assert(t, qt.Equals(group2.Value(), "be"))
+
+	// Fully consume the third group.
+	qt.Assert(t, qt.IsTrue(groups.Next()))
+	k, group3 := groups.Value().Split()
+	qt.Assert(t, qt.Equals(k, 5))
+	values, err := it.ToSlice(group3)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(values, []string{"hello"}))
+	// The groups iterator has not been advanced.
+	qt.Assert(t, qt.Equals(groups.Value().Key, 5))
+
+	// Skipped groups have been discarded.
+	qt.Assert(t, qt.IsFalse(group1.Next()))
+	qt.Assert(t, qt.Equals(group1.Value(), ""))
+	qt.Assert(t, qt.IsFalse(group2.Next()))
+	qt.Assert(t, qt.Equals(group2.Value(), ""))
+
+	// The remaining groups are produced once.
+	var keys []int
+	for groups.Next() {
+		keys = append(keys, groups.Value().Key)
+	}
+	qt.Assert(t, qt.IsNil(groups.Err()))
+	qt.Assert(t, qt.DeepEquals(keys, []int{3, 2, 5}))
+}
+
+func TestGroupBySkipAll(t *testing.T) {
+	words := it.FromSlice([]string{"a", "be", "it", "no", "the"})
+	groups := it.GroupBy(words, func(v string) int {
+		return len(v)
+	})
+	var keys []int
+	for groups.Next() {
+		keys = append(keys, groups.Value().Key)
+	}
+	qt.Assert(t, qt.IsNil(groups.Err()))
+	qt.Assert(t, qt.DeepEquals(keys, []int{1, 2, 3}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(groups.Next()))
+	qt.Assert(t, qt.DeepEquals(groups.Value(), it.KeyValue[int, it.Iterator[string]]{}))
+}
+
+func TestGroupByMaxPending(t *testing.T) {
+	words := it.FromSlice([]string{"a", "be", "it", "no", "the", "are"})
+	groups := it.GroupByWithOptions(words, func(v string) int {
+		return len(v)
+	}, it.GroupByOptions{
+		KeepSkipped: true,
+		MaxPending:  3,
+	})
+	var keys []int
+	for groups.Next() {
+		keys = append(keys, groups.Value().Key)
+	}
+	qt.Assert(t, qt.ErrorIs(groups.Err(), it.ErrBufferFull))
+	qt.Assert(t, qt.DeepEquals(keys, []int{1, 2}))
+}
+
+func TestGroupByMaxPendingConsumed(t *testing.T) {
+	// Consuming groups keeps the number of pending values low.
+	words := it.FromSlice([]string{"a", "be", "it", "no", "the", "are"})
+	groups := it.GroupByWithOptions(words, func(v string) int {
+		return len(v)
+	}, it.GroupByOptions{
+		KeepSkipped: true,
+		MaxPending:  1,
+	})
+	var got [][]string
+	for groups.Next() {
+		values, err := it.ToSlice(groups.Value().Value)
+		qt.Assert(t, qt.IsNil(err))
+		got = append(got, values)
+	}
+	qt.Assert(t, qt.IsNil(groups.Err()))
+	qt.Assert(t, qt.DeepEquals(got, [][]string{{"a"}, {"be", "it", "no"}, {"the", "are"}}))
+}
+
+func TestGroupByError(t *testing.T) {
+	groups := it.GroupBy[string](&errorIterator[string]{
+		v: "engage",
+	}, func(v string) int {
+		return len(v)
+	})
+	qt.Assert(t, qt.IsTrue(groups.Next()))
+	k, group := groups.Value().Split()
+	qt.Assert(t, qt.Equals(k, 6))
+	values, err := it.ToSlice(group)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(values, []string{"engage"}))
+	qt.Assert(t, qt.IsFalse(groups.Next()))
+	qt.Assert(t, qt.ErrorMatches(groups.Err(), "bad wolf"))
+}
+
+func TestGroupByAll(t *testing.T) {
+	words := it.FromSlice([]string{"a", "be", "it", "hello", "no", "the", "are"})
+	groups := it.GroupByAll(words, func(v string) int {
+		return len(v)
+	})
+	got, err := it.ToSlice(groups)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []it.KeyValue[int, []string]{{
+		Key:   1,
+		Value: []string{"a"},
+	}, {
+		Key:   2,
+		Value: []string{"be", "it", "no"},
+	}, {
+		Key:   5,
+		Value: []string{"hello"},
+	}, {
+		Key:   3,
+		Value: []string{"the", "are"},
+	}}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(groups.Next()))
+	qt.Assert(t, qt.DeepEquals(groups.Value(), it.KeyValue[int, []string]{}))
+}
+
+func TestGroupByAllEmpty(t *testing.T) {
+	groups := it.GroupByAll(it.FromSlice([]string{}), func(v string) int {
+		return len(v)
+	})
+	qt.Assert(t, qt.IsFalse(groups.Next()))
+	qt.Assert(t, qt.IsNil(groups.Err()))
+}
+
+func TestGroupByAllError(t *testing.T) {
+	groups := it.GroupByAll[string](&errorIterator[string]{
+		v: "engage",
+	}, func(v string) int {
+		return len(v)
+	})
+	// Groups accumulated before the error are produced.
+	got, err := it.ToSlice(groups)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []it.KeyValue[int, []string]{{
+		Key:   6,
+		Value: []string{"engage"},
+	}}))
+	qt.Assert(t, qt.IsFalse(groups.Next()))
+	qt.Assert(t, qt.DeepEquals(groups.Value(), it.KeyValue[int, []string]{}))
+}
+
+func TestGroupInto(t *testing.T) {
+	words := it.FromSlice([]string{"a", "be", "it", "hello", "no", "the", "are"})
+	groups, err := it.GroupInto(words, func(v string) int {
+		return len(v)
+	})
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(groups, map[int][]string{
+		1: {"a"},
+		2: {"be", "it", "no"},
+		3: {"the", "are"},
+		5: {"hello"},
+	}))
+}
+
+func TestGroupIntoError(t *testing.T) {
+	groups, err := it.GroupInto[string](&errorIterator[string]{
+		v: "engage",
+	}, func(v string) int {
+		return len(v)
+	})
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(groups, map[int][]string{
+		6: {"engage"},
+	}))
+}
+
+func TestGroupReduce(t *testing.T) {
+	words := it.FromSlice([]string{"a", "be", "it", "hello", "no", "the", "are"})
+	counts := it.GroupReduce(words, func(v string) int {
+		return len(v)
+	}, func(a int, v string) int {
+		return a + 1
+	}, func() int {
+		return 0
+	})
+	got, err := it.ToSlice(counts)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []it.KeyValue[int, int]{
+		{Key: 1, Value: 1},
+		{Key: 2, Value: 3},
+		{Key: 5, Value: 1},
+		{Key: 3, Value: 2},
+	}))
+}
+
+func TestGroupReduceInitial(t *testing.T) {
+	// Each group starts from a new accumulated value.
+	words := it.FromSlice([]string{"be", "the", "it", "be", "are"})
+	counts := it.GroupReduce(words, func(v string) int {
+		return len(v)
+	}, func(a map[string]int, v string) map[string]int {
+		a[v]++
+		return a
+	}, func() map[string]int {
+		return make(map[string]int)
+	})
+	got, err := it.ToSlice(counts)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []it.KeyValue[int, map[string]int]{
+		{Key: 2, Value: map[string]int{"be": 2, "it": 1}},
+		{Key: 3, Value: map[string]int{"the": 1, "are": 1}},
+	}))
+}
@@ -0,0 +1,112 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+import "slices"
+
+// Interleave returns an iterator producing values taken from each of the given
+// iterators in turn. Consumed iterators are skipped, so that the iteration
+// continues until all iterators are consumed. As with Chain, the iteration is
+// stopped when any of the iterators has an error.
+//
+// For instance:
+//
+//	values := it.Interleave(it.FromSlice([]int{1, 2, 3}), it.FromSlice([]int{10}), it.FromSlice([]int{20, 21}))
+//	// values produces 1, 10, 20, 2, 21, 3.
+func Interleave[T any](its ...Iterator[T]) Iterator[T] {
+	weights := make([]int, len(its))
+	for i := range weights {
+		weights[i] = 1
+	}
+	return InterleaveWeighted(weights, its...)
+}
+
+// InterleaveWeighted is like Interleave, but at most weights[i] consecutive
+// values are taken from the iterator at index i before moving to the next
+// one. It panics if the number of weights is not the same as the number of
+// iterators, or if any of the weights is less than 1.
+//
+// For instance:
+//
+//	values := it.InterleaveWeighted([]int{2, 1}, it.Count(0, 5, 1), it.Count(10, 15, 1))
+//	// values produces 0, 1, 10, 2, 3, 11, 4, 12, 13, 14.
+func InterleaveWeighted[T any](weights []int, its ...Iterator[T]) Iterator[T] {
+	if len(weights) != len(its) {
+		panic("iterate: invalid weights")
+	}
+	active := make([]weightedIterator[T], len(its))
+	for i, it := range its {
+		if weights[i] < 1 {
+			panic("iterate: invalid weights")
+		}
+		active[i] = weightedIterator[T]{
+			Iterator: it,
+			weight:   weights[i],
+		}
+	}
+	return &interleaver[T]{
+		active: active,
+		all:    its,
+	}
+}
+
+type weightedIterator[T any] struct {
+	Iterator[T]
+	weight int
+}
+
+type interleaver[T any] struct {
+	// active holds the iterators not yet consumed.
+	active []weightedIterator[T]
+	all    []Iterator[T]
+	// idx is the index of the current active iterator, and taken the number
+	// of consecutive values taken from it.
+	idx, taken int
+	value      T
+	err        error
+}
+
+// Next implements Iterator[T].Next by taking values from each iterator in
+// turn.
+func (it *interleaver[T]) Next() bool {
+	for it.err == nil && len(it.active) != 0 {
+		if it.taken == it.active[it.idx].weight {
+			it.idx, it.taken = (it.idx+1)%len(it.active), 0
+		}
+		source := it.active[it.idx]
+		if source.Next() {
+			it.value = source.Value()
+			it.taken++
+			return true
+		}
+		if err := source.Err(); err != nil {
+			it.err = err
+			break
+		}
+		// Drop the consumed iterator and move to the next one.
+		it.active = slices.Delete(it.active, it.idx, it.idx+1)
+		it.taken = 0
+		if it.idx == len(it.active) {
+			it.idx = 0
+		}
+	}
+	it.value = *new(T)
+	return false
+}
+
+// Value implements Iterator[T].Value by returning values from the iterators.
+func (it *interleaver[T]) Value() T {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by propagating the error from the iterator
+// that failed.
+func (it *interleaver[T]) Err() error {
+	return it.err
+}
+
+// Close implements IteratorCloser[T].Close by closing all the iterators,
+// including the ones already consumed.
+func (it *interleaver[T]) Close() error {
+	return closeAll(it.all)
+}
@@ -0,0 +1,55 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestInterleave(t *testing.T) {
+	iter := it.Interleave(it.FromSlice([]int{1, 2, 3}), it.FromSlice([]int{10}), it.FromSlice([]int{}), it.FromSlice([]int{20, 21}))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 10, 20, 2, 21, 3}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+}
+
+func TestInterleaveNoIterators(t *testing.T) {
+	got, err := it.ToSlice(it.Interleave[int]())
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.IsNil(got))
+}
+
+func TestInterleaveError(t *testing.T) {
+	iter := it.Interleave(it.Count(0, 5, 1), &errorIterator[int]{v: 42}, it.Count(10, 15, 1))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []int{0, 42, 10, 1}))
+}
+
+func TestInterleaveWeighted(t *testing.T) {
+	iter := it.InterleaveWeighted([]int{2, 1, 3}, it.Count(0, 5, 1), it.Count(10, 15, 1), it.Count(20, 22, 1))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{0, 1, 10, 20, 21, 2, 3, 11, 4, 12, 13, 14}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+}
+
+func TestInterleaveWeightedInvalidWeights(t *testing.T) {
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.InterleaveWeighted([]int{1}, it.Count(0, 5, 1), it.Count(0, 5, 1))
+	}, "iterate: invalid weights"))
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.InterleaveWeighted([]int{1, 0}, it.Count(0, 5, 1), it.Count(0, 5, 1))
+	}, "iterate: invalid weights"))
+}
@@ -4,18 +4,22 @@ package iterate
 
 import (
 	"bufio"
+	"context"
 	"io"
 )
 
-// Lines returns an iterator producing lines from the given reader.
+// Lines returns an iterator producing lines from the given reader. If the
+// reader is also an io.Closer, it is closed when the iterator is closed.
 func Lines(r io.Reader) Iterator[string] {
 	return &lineReader{
 		scanner: *bufio.NewScanner(r),
+		source:  r,
 	}
 }
 
 type lineReader struct {
 	scanner bufio.Scanner
+	source  io.Reader
 }
 
 // Next implements Iterator[T].Next.
@@ -35,17 +39,34 @@ func (it *lineReader) Err() error {
 	return it.scanner.Err()
 }
 
-// Bytes returns an iterator producing bytes from the given reader.
+// Close implements IteratorCloser[T].Close by closing the reader.
+func (it *lineReader) Close() error {
+	return closeReader(it.source)
+}
+
+// LinesContext returns an iterator producing lines from the given reader until
+// the context is done, in which case Next returns false and Err returns
+// ctx.Err(). If the reader is also an io.Closer, it is closed as soon as the
+// context is done, so that pending reads are unblocked, or when the iterator is
+// closed.
+func LinesContext(ctx context.Context, r io.Reader) Iterator[string] {
+	return WithContext(ctx, Lines(newContextReader(ctx, r)))
+}
+
+// Bytes returns an iterator producing bytes from the given reader. If the
+// reader is also an io.Closer, it is closed when the iterator is closed.
 func Bytes(r io.Reader) Iterator[byte] {
 	return &byteReader{
-		r: *bufio.NewReader(r),
+		r:      *bufio.NewReader(r),
+		source: r,
 	}
 }
 
 type byteReader struct {
-	r   bufio.Reader
-	b   byte
-	err error
+	r      bufio.Reader
+	source io.Reader
+	b      byte
+	err    error
 }
 
 // Next implements Iterator[T].Next by producing the next byte in the reader.
@@ -74,3 +95,74 @@ func (it *byteReader) Value() byte {
 func (it *byteReader) Err() error {
 	return it.err
 }
+
+// Close implements IteratorCloser[T].Close by closing the reader.
+func (it *byteReader) Close() error {
+	return closeReader(it.source)
+}
+
+// BytesContext returns an iterator producing bytes from the given reader until
+// the context is done, in which case Next returns false and Err returns
+// ctx.Err(). If the reader is also an io.Closer, it is closed as soon as the
+// context is done, so that pending reads are unblocked, or when the iterator is
+// closed.
+func BytesContext(ctx context.Context, r io.Reader) Iterator[byte] {
+	return WithContext(ctx, Bytes(newContextReader(ctx, r)))
+}
+
+// contextReader is an io.ReadCloser that stops reading when the context is
+// done.
+type contextReader struct {
+	ctx  context.Context
+	r    io.Reader
+	stop func() bool
+}
+
+// newContextReader returns a reader reading from r until the context is done.
+// If r is an io.Closer, it is closed when the context is done.
+func newContextReader(ctx context.Context, r io.Reader) *contextReader {
+	cr := &contextReader{
+		ctx: ctx,
+		r:   r,
+		stop: func() bool {
+			return true
+		},
+	}
+	if _, ok := r.(io.Closer); ok {
+		cr.stop = context.AfterFunc(ctx, func() {
+			closeReader(r)
+		})
+	}
+	return cr
+}
+
+// Read implements io.Reader by reading from the underlying reader. The context
+// error is returned if the context is done.
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	n, err := r.r.Read(p)
+	if err != nil && r.ctx.Err() != nil {
+		// The read has been likely interrupted by closing the reader.
+		return n, r.ctx.Err()
+	}
+	return n, err
+}
+
+// Close implements io.Closer by closing the underlying reader, unless it has
+// been already closed because the context is done.
+func (r *contextReader) Close() error {
+	if !r.stop() {
+		return nil
+	}
+	return closeReader(r.r)
+}
+
+// closeReader closes the given reader if it is an io.Closer.
+func closeReader(r io.Reader) error {
+	if c, ok := r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -4,7 +4,9 @@ package iterate_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"io"
 	"strings"
 	"testing"
 
@@ -50,9 +52,84 @@ func TestBytesError(t *testing.T) {
 	qt.Assert(t, qt.ErrorMatches(bytes.Err(), "bad wolf"))
 }
 
+func TestLinesContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r, w := io.Pipe()
+	lines := it.LinesContext(ctx, r)
+	go func() {
+		io.WriteString(w, "hello\n")
+		// Unblock the pending read.
+		cancel()
+	}()
+
+	vs, err := it.ToSlice(lines)
+	qt.Assert(t, qt.ErrorIs(err, context.Canceled))
+	qt.Assert(t, qt.DeepEquals(vs, []string{"hello"}))
+
+	// The reader has been closed.
+	_, err = w.Write([]byte("world\n"))
+	qt.Assert(t, qt.ErrorIs(err, io.ErrClosedPipe))
+
+	// Closing the iterator does not close the reader again.
+	qt.Assert(t, qt.IsNil(it.Close(lines)))
+}
+
+func TestBytesContext(t *testing.T) {
+	r := &closerReader{
+		Reader: strings.NewReader("hello"),
+	}
+	b, err := it.ToSlice(it.BytesContext(context.Background(), r))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.Equals(string(b), "hello"))
+	qt.Assert(t, qt.IsFalse(r.closed))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b, err = it.ToSlice(it.BytesContext(ctx, strings.NewReader("hello")))
+	qt.Assert(t, qt.ErrorIs(err, context.Canceled))
+	qt.Assert(t, qt.HasLen(b, 0))
+}
+
 // errReader is a io.Reader implementation that always return an error.
 type errReader struct{}
 
 func (errReader) Read(p []byte) (n int, err error) {
 	return 0, errors.New("bad wolf")
 }
+
+func TestLinesClose(t *testing.T) {
+	r := &closerReader{
+		Reader: strings.NewReader("hello\nworld"),
+	}
+	lines := it.Limit(it.Lines(r), 1)
+	vs, err := it.ToSlice(lines)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(vs, []string{"hello"}))
+
+	qt.Assert(t, qt.IsNil(it.Close(lines)))
+	qt.Assert(t, qt.IsTrue(r.closed))
+}
+
+func TestBytesClose(t *testing.T) {
+	r := &closerReader{
+		Reader: strings.NewReader("hello"),
+	}
+	b := it.Bytes(r)
+	qt.Assert(t, qt.IsNil(it.Close(b)))
+	qt.Assert(t, qt.IsTrue(r.closed))
+
+	// Readers not implementing io.Closer are left alone.
+	qt.Assert(t, qt.IsNil(it.Close(it.Bytes(strings.NewReader("hello")))))
+}
+
+// closerReader is an io.ReadCloser recording whether it has been closed.
+type closerReader struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closerReader) Close() error {
+	r.closed = true
+	return nil
+}
@@ -2,6 +2,11 @@
 
 package iterate
 
+import (
+	"errors"
+	"io"
+)
+
 // Iterator is implemented by types producing values of type T. Implementations
 // are typically used in for loops, for instance:
 //
@@ -15,6 +20,9 @@ package iterate
 //
 // Depending on the implementation, producing values might lead to errors. For
 // this reason it is important to always check Err() after iterating.
+//
+// Iterators can also be used in range loops by converting them to sequences
+// with Seq or Seq2. Sequences can be converted back with FromSeq or FromSeq2.
 type Iterator[T any] interface {
 	// Next advances the iterator. The next value can be then retrieved using
 	// the Value method. False is returned when the iteration is done. Further
@@ -31,3 +39,38 @@ type Iterator[T any] interface {
 	// Err returns the first error occurred while iterating.
 	Err() error
 }
+
+// IteratorCloser is implemented by iterators holding resources, like files or
+// network connections, that must be released when the iteration is done.
+//
+// All the iterators in this package wrapping other iterators implement
+// IteratorCloser by closing their sources, so that closing the outermost
+// iterator releases resources along the whole chain, for instance:
+//
+//	lines := it.Limit(it.Lines(f), 10)
+//	defer it.Close(lines) // This also closes f.
+type IteratorCloser[T any] interface {
+	Iterator[T]
+
+	// Close releases the resources associated with the iterator. The iterator
+	// must not be used after being closed.
+	Close() error
+}
+
+// Close closes the given iterator if it implements IteratorCloser. Nil is
+// returned otherwise.
+func Close[T any](it Iterator[T]) error {
+	if c, ok := it.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// closeAll closes all the given iterators, returning the joined errors.
+func closeAll[T any](its []Iterator[T]) error {
+	errs := make([]error, 0, len(its))
+	for _, it := range its {
+		errs = append(errs, Close(it))
+	}
+	return errors.Join(errs...)
+}
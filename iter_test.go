@@ -0,0 +1,188 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestClose(t *testing.T) {
+	// Iterators not implementing IteratorCloser can be closed.
+	qt.Assert(t, qt.IsNil(it.Close(it.Count(0, 10, 1))))
+
+	// Errors are propagated.
+	iter := &closerIterator[int]{
+		Iterator: it.Count(0, 10, 1),
+		err:      errors.New("bad wolf"),
+	}
+	qt.Assert(t, qt.ErrorMatches(it.Close[int](iter), "bad wolf"))
+	qt.Assert(t, qt.Equals(iter.closed, 1))
+}
+
+var closePropagationTests = []struct {
+	about   string
+	wrap    func(sources ...it.Iterator[int]) it.Iterator[int]
+	sources int
+}{{
+	about: "filter",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.Filter(sources[0], func(v int) bool {
+			return true
+		})
+	},
+	sources: 1,
+}, {
+	about: "map",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.Map(sources[0], func(v int) int {
+			return v * 2
+		})
+	},
+	sources: 1,
+}, {
+	about: "chain",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.Chain(sources[0], sources[1:]...)
+	},
+	sources: 3,
+}, {
+	about: "repeat",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.Repeat(sources[0])
+	},
+	sources: 1,
+}, {
+	about: "tee",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.Tee(sources[0], func(v int) {})
+	},
+	sources: 1,
+}, {
+	about: "drop while",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.DropWhile(sources[0], func(idx, v int) bool {
+			return idx < 2
+		})
+	},
+	sources: 1,
+}, {
+	about: "limit",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.Limit(sources[0], 2)
+	},
+	sources: 1,
+}, {
+	about: "enumerate",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.Map(it.Enumerate(sources[0]), func(kv it.KeyValue[int, int]) int {
+			return kv.Key
+		})
+	},
+	sources: 1,
+}, {
+	about: "zip",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.Map(it.Zip(sources[0], sources[1]), func(kv it.KeyValue[int, int]) int {
+			return kv.Key + kv.Value
+		})
+	},
+	sources: 2,
+}, {
+	about: "group by",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		groups := it.GroupBy(sources[0], func(v int) bool {
+			return v%2 == 0
+		})
+		return it.Map(groups, func(kv it.KeyValue[bool, it.Iterator[int]]) int {
+			return 0
+		})
+	},
+	sources: 1,
+}, {
+	about: "sorted",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.Sorted(sources[0], intLess)
+	},
+	sources: 1,
+}, {
+	about: "top k",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.TopK(sources[0], 3, intLess)
+	},
+	sources: 1,
+}, {
+	about: "product",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.Map(it.Product(sources...), func(v []int) int {
+			return v[0]
+		})
+	},
+	sources: 2,
+}, {
+	about: "interleave",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.Interleave(sources...)
+	},
+	sources: 3,
+}, {
+	about: "slice",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.Slice(sources[0], 1, -1, 2)
+	},
+	sources: 1,
+}, {
+	about: "take last",
+	wrap: func(sources ...it.Iterator[int]) it.Iterator[int] {
+		return it.TakeLast(sources[0], 3)
+	},
+	sources: 1,
+}}
+
+func TestClosePropagation(t *testing.T) {
+	for _, test := range closePropagationTests {
+		t.Run(test.about, func(t *testing.T) {
+			sources := make([]*closerIterator[int], test.sources)
+			iters := make([]it.Iterator[int], test.sources)
+			for i := range sources {
+				sources[i] = &closerIterator[int]{
+					Iterator: it.Count(0, 10, 1),
+				}
+				iters[i] = sources[i]
+			}
+			iter := test.wrap(iters...)
+			qt.Assert(t, qt.IsTrue(iter.Next()))
+			qt.Assert(t, qt.IsNil(it.Close(iter)))
+			for _, source := range sources {
+				qt.Assert(t, qt.Equals(source.closed, 1))
+			}
+		})
+	}
+}
+
+func TestClosePropagationErrors(t *testing.T) {
+	iter := it.Chain[int](&closerIterator[int]{
+		Iterator: it.Count(0, 10, 1),
+		err:      errors.New("bad wolf"),
+	}, &closerIterator[int]{
+		Iterator: it.Count(0, 10, 1),
+		err:      errors.New("ice"),
+	})
+	qt.Assert(t, qt.ErrorMatches(it.Close(iter), "bad wolf\nice"))
+}
+
+// closerIterator is an iterator recording how many times it has been closed.
+type closerIterator[T any] struct {
+	it.Iterator[T]
+	closed int
+	err    error
+}
+
+func (it *closerIterator[T]) Close() error {
+	it.closed++
+	return it.err
+}
@@ -13,140 +13,62 @@ func (kv KeyValue[K, V]) Split() (K, V) {
 	return kv.Key, kv.Value
 }
 
-// Zip returns an iterator of key/value pairs produced by the given key/value
-// iterators. The shorter of the two iterators is used. An error is returned if
-// any of the two iterators returns an error.
-func Zip[K comparable, V any](keys Iterator[K], values Iterator[V]) Iterator[KeyValue[K, V]] {
-	return &zipper[K, V]{
-		keys:   keys,
-		values: values,
+// Pair returns the key/value pair as a Pair.
+func (kv KeyValue[K, V]) Pair() Pair[K, V] {
+	return Pair[K, V]{
+		First:  kv.Key,
+		Second: kv.Value,
 	}
 }
 
-type zipper[K comparable, V any] struct {
-	keys    Iterator[K]
-	values  Iterator[V]
-	stopped bool
-}
-
-// Next implements Iterator[T].Next.
-func (it *zipper[K, V]) Next() bool {
-	if it.keys.Next() && it.values.Next() {
-		return true
-	}
-	it.stopped = true
-	return false
+// ToPairs returns an iterator converting key/value pairs produced by the given
+// iterator into pairs.
+func ToPairs[K comparable, V any](it Iterator[KeyValue[K, V]]) Iterator[Pair[K, V]] {
+	return Map(it, KeyValue[K, V].Pair)
 }
 
-// Value implements Iterator[T].Value by returning key/value pairs.
-func (it *zipper[K, V]) Value() KeyValue[K, V] {
-	if it.stopped {
-		return KeyValue[K, V]{}
-	}
-	return KeyValue[K, V]{
-		Key:   it.keys.Value(),
-		Value: it.values.Value(),
-	}
+// ToKeyValues returns an iterator converting pairs produced by the given
+// iterator into key/value pairs.
+func ToKeyValues[K comparable, V any](it Iterator[Pair[K, V]]) Iterator[KeyValue[K, V]] {
+	return Map(it, func(p Pair[K, V]) KeyValue[K, V] {
+		return KeyValue[K, V]{
+			Key:   p.First,
+			Value: p.Second,
+		}
+	})
 }
 
-// Err implements Iterator[T].Err by propagating the error from the keys and
-// values iterators.
-func (it *zipper[K, V]) Err() error {
-	if err := it.keys.Err(); err != nil {
-		return err
-	}
-	return it.values.Err()
+// Zip returns an iterator of key/value pairs produced by the given key/value
+// iterators. The shorter of the two iterators is used. The errors from both
+// iterators are returned. See ZipPairs for zipping non comparable keys.
+func Zip[K comparable, V any](keys Iterator[K], values Iterator[V]) Iterator[KeyValue[K, V]] {
+	return ToKeyValues(ZipPairs(keys, values))
 }
 
 // Unzip returns a key iterator and a value iterator with pairs produced by the
-// given key/value iterator.
+// given key/value iterator. The two iterators can be safely consumed from
+// different goroutines. Values are buffered until retrieved by the slower of
+// the two iterators: see UnzipWithOptions for limiting the buffer size. When
+// closed, the two iterators close the source key/value iterator only once both
+// of them are closed. See UnzipPairs for unzipping non comparable keys.
 func Unzip[K comparable, V any](kvs Iterator[KeyValue[K, V]]) (Iterator[K], Iterator[V]) {
-	u := unzipper[K, V]{
-		kvs: kvs,
-	}
-	return u.iterators()
-}
-
-type unzipper[K comparable, V any] struct {
-	kvs    Iterator[KeyValue[K, V]]
-	keys   []K
-	values []V
-}
-
-func (u *unzipper[K, V]) iterators() (Iterator[K], Iterator[V]) {
-	return &keyIterator[K, V]{
-			u: u,
-		}, &valueIterator[K, V]{
-			u: u,
-		}
-}
-
-type keyIterator[K comparable, V any] struct {
-	u   *unzipper[K, V]
-	key K
-}
-
-// Next implements Iterator[T].Next.
-func (it *keyIterator[K, V]) Next() bool {
-	// TODO(frankban): make this thread safe.
-	if len(it.u.keys) != 0 {
-		it.key, it.u.keys = it.u.keys[0], it.u.keys[1:]
-		return true
-	}
-	if it.u.kvs.Next() {
-		kv := it.u.kvs.Value()
-		it.key = kv.Key
-		it.u.values = append(it.u.values, kv.Value)
-		return true
-	}
-	it.key = *new(K)
-	return false
-}
-
-// Value implements Iterator[T].Value by returning keys from the key/value
-// iterator stored in the unzipper.
-func (it *keyIterator[K, V]) Value() K {
-	return it.key
-}
-
-// Err implements Iterator[T].Err by propagating the error from the key/value
-// source iterator.
-func (it *keyIterator[K, V]) Err() error {
-	return it.u.kvs.Err()
-}
-
-type valueIterator[K comparable, V any] struct {
-	u     *unzipper[K, V]
-	value V
-}
-
-// Next implements Iterator[T].Next.
-func (it *valueIterator[K, V]) Next() bool {
-	// TODO(frankban): make this thread safe.
-	if len(it.u.values) != 0 {
-		it.value, it.u.values = it.u.values[0], it.u.values[1:]
-		return true
-	}
-	if it.u.kvs.Next() {
-		kv := it.u.kvs.Value()
-		it.value = kv.Value
-		it.u.keys = append(it.u.keys, kv.Key)
-		return true
-	}
-	it.value = *new(V)
-	return false
-}
-
-// Value implements Iterator[T].Value by returning values from the key/value
-// iterator stored in the unzipper.
-func (it *valueIterator[K, V]) Value() V {
-	return it.value
-}
-
-// Err implements Iterator[T].Err by propagating the error from the key/value
-// source iterator.
-func (it *valueIterator[K, V]) Err() error {
-	return it.u.kvs.Err()
+	return UnzipPairs(ToPairs(kvs))
+}
+
+// UnzipWithOptions is like Unzip, but the given options are used for
+// controlling how values are buffered.
+//
+// For instance:
+//
+//	keys, values := it.UnzipWithOptions(kvs, it.UnzipOptions{MaxPending: 100})
+//	for keys.Next() {
+//		// Do something with keys.Value().
+//	}
+//	if err := keys.Err(); errors.Is(err, it.ErrBufferFull) {
+//		// More than 100 values are waiting to be retrieved from values.
+//	}
+func UnzipWithOptions[K comparable, V any](kvs Iterator[KeyValue[K, V]], opts UnzipOptions) (Iterator[K], Iterator[V]) {
+	return UnzipPairsWithOptions(ToPairs(kvs), opts)
 }
 
 // ToMap returns a map with the values produced by the given key/value iterator.
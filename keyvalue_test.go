@@ -3,6 +3,8 @@
 package iterate_test
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/go-quicktest/qt"
@@ -20,6 +22,32 @@ func TestKeyValueSplit(t *testing.T) {
 	qt.Assert(t, qt.Equals(v, "engage"))
 }
 
+func TestKeyValuePair(t *testing.T) {
+	kv := it.KeyValue[int, string]{
+		Key:   42,
+		Value: "engage",
+	}
+	qt.Assert(t, qt.Equals(kv.Pair(), it.Pair[int, string]{
+		First:  42,
+		Second: "engage",
+	}))
+}
+
+func TestKeyValuesPairsRoundtrip(t *testing.T) {
+	s := makeKeyValues()
+	pairs, err := it.ToSlice(it.ToPairs(it.FromSlice(s)))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.HasLen(pairs, len(s)))
+	qt.Assert(t, qt.Equals(pairs[2], it.Pair[int, string]{
+		First:  42,
+		Second: "the",
+	}))
+
+	got, err := it.ToSlice(it.ToKeyValues(it.FromSlice(pairs)))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, s))
+}
+
 func TestZip(t *testing.T) {
 	keys := it.FromSlice([]string{"a", "b", "c"})
 	values := it.FromSlice([]int{4, 3, 2, 1})
@@ -194,3 +222,131 @@ func makeKeyValues() []it.KeyValue[int, string] {
 		Value: "voyages",
 	}}
 }
+
+func TestUnzipClose(t *testing.T) {
+	source := &closerIterator[it.KeyValue[int, string]]{
+		Iterator: it.FromSlice(makeKeyValues()),
+	}
+	keys, values := it.Unzip[int, string](source)
+
+	// The source is only closed when both iterators are closed.
+	qt.Assert(t, qt.IsNil(it.Close(keys)))
+	qt.Assert(t, qt.IsNil(it.Close(keys)))
+	qt.Assert(t, qt.Equals(source.closed, 0))
+
+	qt.Assert(t, qt.IsNil(it.Close(values)))
+	qt.Assert(t, qt.Equals(source.closed, 1))
+}
+
+func TestUnzipConcurrent(t *testing.T) {
+	kvs := it.Zip(it.Count(0, 1000, 1), it.Count(0, 1000, 1))
+	keys, values := it.Unzip(kvs)
+
+	var wg sync.WaitGroup
+	var ksum, vsum int
+	var kerr, verr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		ksum, kerr = it.Sum(keys)
+	}()
+	go func() {
+		defer wg.Done()
+		vsum, verr = it.Sum(values)
+	}()
+	wg.Wait()
+
+	qt.Assert(t, qt.IsNil(kerr))
+	qt.Assert(t, qt.IsNil(verr))
+	qt.Assert(t, qt.Equals(ksum, 499500))
+	qt.Assert(t, qt.Equals(vsum, 499500))
+}
+
+func TestUnzipWithOptionsBlock(t *testing.T) {
+	source := &countingIterator[it.KeyValue[int, string]]{
+		Iterator: it.FromSlice(makeKeyValues()),
+	}
+	keys, values := it.UnzipWithOptions[int, string](source, it.UnzipOptions{
+		MaxPending: 2,
+		Block:      true,
+	})
+
+	// The goroutine signals on ready before each call to Next, and sends the
+	// produced keys on progress.
+	ready, progress := make(chan struct{}), make(chan int)
+	go func() {
+		for {
+			ready <- struct{}{}
+			if !keys.Next() {
+				break
+			}
+			progress <- keys.Value()
+		}
+		close(progress)
+	}()
+
+	// Keys can be consumed until the buffer for values is full.
+	<-ready
+	qt.Assert(t, qt.Equals(<-progress, 1))
+	<-ready
+	qt.Assert(t, qt.Equals(<-progress, 2))
+	<-ready
+	// The keys iterator is now blocked in Next, without advancing the source.
+	qt.Assert(t, qt.Equals(source.calls.Load(), 2))
+
+	// Consuming a value unblocks the keys iterator.
+	qt.Assert(t, qt.IsTrue(values.Next()))
+	qt.Assert(t, qt.Equals(values.Value(), "these"))
+	qt.Assert(t, qt.Equals(<-progress, 42))
+
+	// Closing the values iterator unblocks the keys iterator.
+	<-ready
+	qt.Assert(t, qt.IsNil(it.Close(values)))
+	qt.Assert(t, qt.Equals(<-progress, 47))
+	<-ready
+	_, ok := <-progress
+	qt.Assert(t, qt.IsFalse(ok))
+	qt.Assert(t, qt.IsNil(keys.Err()))
+}
+
+func TestUnzipWithOptions(t *testing.T) {
+	keys, values := it.UnzipWithOptions(it.FromSlice(makeKeyValues()), it.UnzipOptions{
+		MaxPending: 2,
+	})
+
+	// Keys are produced until the buffer for values is full.
+	ks, err := it.ToSlice(keys)
+	qt.Assert(t, qt.ErrorIs(err, it.ErrBufferFull))
+	qt.Assert(t, qt.DeepEquals(ks, []int{1, 2}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(keys.Next()))
+	qt.Assert(t, qt.Equals(keys.Value(), 0))
+
+	// Values can still be consumed.
+	vs, err := it.ToSlice(values)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(vs, []string{"these", "are", "the", "voyages"}))
+}
+
+func TestUnzipWithOptionsNoLimit(t *testing.T) {
+	keys, values := it.UnzipWithOptions(it.FromSlice(makeKeyValues()), it.UnzipOptions{})
+	ks, err := it.ToSlice(keys)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(ks, []int{1, 2, 42, 47}))
+	vs, err := it.ToSlice(values)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(vs, []string{"these", "are", "the", "voyages"}))
+}
+
+// countingIterator counts the calls to Next. It can be safely inspected from
+// different goroutines.
+type countingIterator[T any] struct {
+	it.Iterator[T]
+	calls atomic.Int64
+}
+
+func (it *countingIterator[T]) Next() bool {
+	it.calls.Add(1)
+	return it.Iterator.Next()
+}
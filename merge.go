@@ -0,0 +1,142 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+import "container/heap"
+
+// MergeSorted returns an iterator producing values from the given iterators,
+// each of them producing values sorted according to the given less function,
+// in sorted order. Values comparing equal are produced in the order of the
+// iterators they come from. Values are pulled from each iterator only when
+// required. The iteration is stopped when all iterators are consumed or when
+// any of them has an error.
+//
+// For instance:
+//
+//	values := it.MergeSorted(func(a, b int) bool {
+//		return a < b
+//	}, it.FromSlice([]int{1, 4, 7}), it.FromSlice([]int{2, 3, 8}))
+//	// values produces 1, 2, 3, 4, 7, 8.
+func MergeSorted[T any](less func(a, b T) bool, its ...Iterator[T]) Iterator[T] {
+	return &merger[T]{
+		sources: its,
+		heap: mergeHeap[T]{
+			less: less,
+		},
+		last: -1,
+	}
+}
+
+type merger[T any] struct {
+	sources []Iterator[T]
+	heap    mergeHeap[T]
+	started bool
+	// last holds the index of the iterator that produced the current value.
+	last  int
+	value T
+	err   error
+}
+
+// Next implements Iterator[T].Next.
+func (it *merger[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if !it.started {
+		it.started = true
+		for i := range it.sources {
+			if !it.advance(i) {
+				return false
+			}
+		}
+	} else if it.last != -1 && !it.advance(it.last) {
+		return false
+	}
+	if it.heap.Len() == 0 {
+		it.value, it.last = *new(T), -1
+		return false
+	}
+	entry := heap.Pop(&it.heap).(mergeEntry[T])
+	it.value, it.last = entry.value, entry.idx
+	return true
+}
+
+// advance pulls the next value from the iterator at the given index, pushing
+// it onto the heap. It reports whether the iteration can continue.
+func (it *merger[T]) advance(idx int) bool {
+	source := it.sources[idx]
+	if source.Next() {
+		heap.Push(&it.heap, mergeEntry[T]{
+			value: source.Value(),
+			idx:   idx,
+		})
+		return true
+	}
+	if err := source.Err(); err != nil {
+		it.err = err
+		it.value, it.last = *new(T), -1
+		return false
+	}
+	return true
+}
+
+// Value implements Iterator[T].Value by returning values in sorted order.
+func (it *merger[T]) Value() T {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by propagating the error from the iterator
+// that failed.
+func (it *merger[T]) Err() error {
+	return it.err
+}
+
+// Close implements IteratorCloser[T].Close by closing all the iterators.
+func (it *merger[T]) Close() error {
+	return closeAll(it.sources)
+}
+
+// mergeEntry is a value stored in the merge heap, with the index of the
+// iterator that produced it.
+type mergeEntry[T any] struct {
+	value T
+	idx   int
+}
+
+// mergeHeap implements heap.Interface. Entries with equal values are ordered
+// by iterator index, so that merging is stable.
+type mergeHeap[T any] struct {
+	entries []mergeEntry[T]
+	less    func(a, b T) bool
+}
+
+func (h *mergeHeap[T]) Len() int {
+	return len(h.entries)
+}
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	a, b := h.entries[i], h.entries[j]
+	if h.less(a.value, b.value) {
+		return true
+	}
+	if h.less(b.value, a.value) {
+		return false
+	}
+	return a.idx < b.idx
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+}
+
+func (h *mergeHeap[T]) Push(x any) {
+	h.entries = append(h.entries, x.(mergeEntry[T]))
+}
+
+func (h *mergeHeap[T]) Pop() any {
+	n := len(h.entries) - 1
+	entry := h.entries[n]
+	h.entries[n] = mergeEntry[T]{}
+	h.entries = h.entries[:n]
+	return entry
+}
@@ -0,0 +1,95 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestMergeSorted(t *testing.T) {
+	iter := it.MergeSorted(intLess,
+		it.FromSlice([]int{1, 4, 7}),
+		it.FromSlice([]int{}),
+		it.FromSlice([]int{2, 3, 8, 9}),
+		it.FromSlice([]int{0, 5}))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{0, 1, 2, 3, 4, 5, 7, 8, 9}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+}
+
+func TestMergeSortedNoIterators(t *testing.T) {
+	got, err := it.ToSlice(it.MergeSorted(intLess))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.IsNil(got))
+}
+
+func TestMergeSortedStable(t *testing.T) {
+	type entry struct {
+		TS     int
+		Source string
+	}
+	makeEntries := func(source string, tss ...int) it.Iterator[entry] {
+		entries := make([]entry, len(tss))
+		for i, ts := range tss {
+			entries[i] = entry{TS: ts, Source: source}
+		}
+		return it.FromSlice(entries)
+	}
+	iter := it.MergeSorted(func(a, b entry) bool {
+		return a.TS < b.TS
+	}, makeEntries("a", 1, 2, 2), makeEntries("b", 1, 2), makeEntries("c", 0, 2))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []entry{
+		{TS: 0, Source: "c"},
+		{TS: 1, Source: "a"},
+		{TS: 1, Source: "b"},
+		{TS: 2, Source: "a"},
+		{TS: 2, Source: "a"},
+		{TS: 2, Source: "b"},
+		{TS: 2, Source: "c"},
+	}))
+}
+
+func TestMergeSortedLazy(t *testing.T) {
+	var pulled []int
+	record := func(v int) {
+		pulled = append(pulled, v)
+	}
+	iter := it.MergeSorted(intLess,
+		it.Tee(it.FromSlice([]int{1, 2, 3}), record),
+		it.Tee(it.FromSlice([]int{10, 20}), record))
+
+	qt.Assert(t, qt.IsTrue(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 1))
+	qt.Assert(t, qt.DeepEquals(pulled, []int{1, 10}))
+
+	qt.Assert(t, qt.IsTrue(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 2))
+	qt.Assert(t, qt.DeepEquals(pulled, []int{1, 10, 2}))
+}
+
+func TestMergeSortedError(t *testing.T) {
+	iter := it.MergeSorted(intLess,
+		it.FromSlice([]int{1, 4, 7}),
+		it.Chain[int](it.FromSlice([]int{2, 3}), &errorIterator[int]{v: 5}))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 2, 3, 4, 5}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+}
+
+func intLess(a, b int) bool {
+	return a < b
+}
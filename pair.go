@@ -0,0 +1,306 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+import (
+	"errors"
+	"sync"
+)
+
+// Pair represents a pair of values. Unlike KeyValue, the first value is not
+// required to be comparable, so that pairs can hold slices, maps or functions.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Split return the first and the second value.
+func (p Pair[A, B]) Split() (A, B) {
+	return p.First, p.Second
+}
+
+// ZipPairs returns an iterator of pairs produced by the given iterators. The
+// shorter of the two iterators is used. The errors from both iterators are
+// returned.
+func ZipPairs[A, B any](first Iterator[A], second Iterator[B]) Iterator[Pair[A, B]] {
+	return &zipper[A, B]{
+		first:  first,
+		second: second,
+	}
+}
+
+type zipper[A, B any] struct {
+	first   Iterator[A]
+	second  Iterator[B]
+	stopped bool
+}
+
+// Next implements Iterator[T].Next.
+func (it *zipper[A, B]) Next() bool {
+	if it.first.Next() && it.second.Next() {
+		return true
+	}
+	it.stopped = true
+	return false
+}
+
+// Value implements Iterator[T].Value by returning pairs.
+func (it *zipper[A, B]) Value() Pair[A, B] {
+	if it.stopped {
+		return Pair[A, B]{}
+	}
+	return Pair[A, B]{
+		First:  it.first.Value(),
+		Second: it.second.Value(),
+	}
+}
+
+// Err implements Iterator[T].Err by joining the errors from the first and
+// second iterators.
+func (it *zipper[A, B]) Err() error {
+	return errors.Join(it.first.Err(), it.second.Err())
+}
+
+// Close implements IteratorCloser[T].Close by closing the first and second
+// iterators.
+func (it *zipper[A, B]) Close() error {
+	return errors.Join(Close(it.first), Close(it.second))
+}
+
+// UnzipPairs returns an iterator of first values and an iterator of second
+// values with pairs produced by the given iterator. The two iterators can be
+// safely consumed from different goroutines. Values produced by the source
+// iterator are buffered until retrieved by the slower of the two iterators:
+// see UnzipPairsWithOptions for limiting the buffer size. When closed, the two
+// iterators close the source iterator only once both of them are closed.
+func UnzipPairs[A, B any](pairs Iterator[Pair[A, B]]) (Iterator[A], Iterator[B]) {
+	return UnzipPairsWithOptions(pairs, UnzipOptions{})
+}
+
+// UnzipOptions holds options for UnzipWithOptions and UnzipPairsWithOptions.
+type UnzipOptions struct {
+	// MaxPending, if not zero, is the maximum number of values buffered for
+	// the slower iterator, waiting to be retrieved.
+	MaxPending int
+
+	// Block specifies what happens when the faster iterator would exceed
+	// MaxPending. If true, calls to Next on the faster iterator block until
+	// the other iterator catches up or is closed, so the two iterators must
+	// be consumed from different goroutines: consuming both of them from the
+	// same goroutine deadlocks as soon as one of them gets more than
+	// MaxPending values ahead of the other. If false, the iteration of the
+	// faster iterator is stopped and its Err method returns ErrBufferFull,
+	// while the other iterator can still be consumed.
+	Block bool
+}
+
+// UnzipPairsWithOptions is like UnzipPairs, but the given options are used for
+// controlling how values are buffered.
+func UnzipPairsWithOptions[A, B any](pairs Iterator[Pair[A, B]], opts UnzipOptions) (Iterator[A], Iterator[B]) {
+	u := &unzipper[A, B]{
+		pairs: pairs,
+		opts:  opts,
+	}
+	u.cond = sync.NewCond(&u.mu)
+	return &firstIterator[A, B]{
+		u: u,
+	}, &secondIterator[A, B]{
+		u: u,
+	}
+}
+
+type unzipper[A, B any] struct {
+	pairs Iterator[Pair[A, B]]
+	opts  UnzipOptions
+
+	// mu protects all the fields below.
+	mu                        sync.Mutex
+	cond                      *sync.Cond
+	first                     []A
+	second                    []B
+	firstClosed, secondClosed bool
+	// firstFull and secondFull are true when the corresponding iterator has
+	// been stopped with ErrBufferFull.
+	firstFull, secondFull bool
+	done                  bool
+}
+
+// nextFirst returns the next first value, advancing the source iterator if
+// required.
+func (u *unzipper[A, B]) nextFirst() (A, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for {
+		if len(u.first) != 0 {
+			var v A
+			v, u.first = u.first[0], u.first[1:]
+			// Wake up the second iterator in the case it is waiting for
+			// buffer space.
+			u.cond.Broadcast()
+			return v, true
+		}
+		if u.done || u.firstFull {
+			return *new(A), false
+		}
+		if u.full(len(u.second), u.secondClosed || u.secondFull) {
+			if !u.opts.Block {
+				u.firstFull = true
+				return *new(A), false
+			}
+			// Wait for the second iterator to catch up.
+			u.cond.Wait()
+			continue
+		}
+		if !u.pairs.Next() {
+			u.done = true
+			u.cond.Broadcast()
+			return *new(A), false
+		}
+		p := u.pairs.Value()
+		if !u.secondClosed && !u.secondFull {
+			u.second = append(u.second, p.Second)
+		}
+		return p.First, true
+	}
+}
+
+// nextSecond returns the next second value, advancing the source iterator if
+// required.
+func (u *unzipper[A, B]) nextSecond() (B, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for {
+		if len(u.second) != 0 {
+			var v B
+			v, u.second = u.second[0], u.second[1:]
+			// Wake up the first iterator in the case it is waiting for buffer
+			// space.
+			u.cond.Broadcast()
+			return v, true
+		}
+		if u.done || u.secondFull {
+			return *new(B), false
+		}
+		if u.full(len(u.first), u.firstClosed || u.firstFull) {
+			if !u.opts.Block {
+				u.secondFull = true
+				return *new(B), false
+			}
+			// Wait for the first iterator to catch up.
+			u.cond.Wait()
+			continue
+		}
+		if !u.pairs.Next() {
+			u.done = true
+			u.cond.Broadcast()
+			return *new(B), false
+		}
+		p := u.pairs.Value()
+		if !u.firstClosed && !u.firstFull {
+			u.first = append(u.first, p.First)
+		}
+		return p.Second, true
+	}
+}
+
+// full reports whether a buffer with the given length for the other iterator
+// is full. Buffers for stopped iterators are never full.
+func (u *unzipper[A, B]) full(n int, stopped bool) bool {
+	return u.opts.MaxPending > 0 && n >= u.opts.MaxPending && !stopped
+}
+
+// err returns the error for the first or second iterator.
+func (u *unzipper[A, B]) err(first bool) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if first && u.firstFull || !first && u.secondFull {
+		return ErrBufferFull
+	}
+	return u.pairs.Err()
+}
+
+// close marks the first or second iterator as closed, discarding its buffered
+// values. The source iterator is closed when both iterators are closed.
+func (u *unzipper[A, B]) close(first bool) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if first {
+		if u.firstClosed {
+			return nil
+		}
+		u.first, u.firstClosed = nil, true
+	} else {
+		if u.secondClosed {
+			return nil
+		}
+		u.second, u.secondClosed = nil, true
+	}
+	// Wake up the other iterator in the case it is waiting for buffer space.
+	u.cond.Broadcast()
+	if u.firstClosed && u.secondClosed {
+		return Close(u.pairs)
+	}
+	return nil
+}
+
+type firstIterator[A, B any] struct {
+	u     *unzipper[A, B]
+	value A
+}
+
+// Next implements Iterator[T].Next.
+func (it *firstIterator[A, B]) Next() bool {
+	var ok bool
+	it.value, ok = it.u.nextFirst()
+	return ok
+}
+
+// Value implements Iterator[T].Value by returning first values from the pairs
+// iterator stored in the unzipper.
+func (it *firstIterator[A, B]) Value() A {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by propagating the error from the pairs
+// source iterator, or by returning ErrBufferFull if too many values are
+// buffered for the second values iterator.
+func (it *firstIterator[A, B]) Err() error {
+	return it.u.err(true)
+}
+
+// Close implements IteratorCloser[T].Close. The source pairs iterator is
+// closed when the corresponding second values iterator is closed as well.
+func (it *firstIterator[A, B]) Close() error {
+	return it.u.close(true)
+}
+
+type secondIterator[A, B any] struct {
+	u     *unzipper[A, B]
+	value B
+}
+
+// Next implements Iterator[T].Next.
+func (it *secondIterator[A, B]) Next() bool {
+	var ok bool
+	it.value, ok = it.u.nextSecond()
+	return ok
+}
+
+// Value implements Iterator[T].Value by returning second values from the pairs
+// iterator stored in the unzipper.
+func (it *secondIterator[A, B]) Value() B {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by propagating the error from the pairs
+// source iterator, or by returning ErrBufferFull if too many values are
+// buffered for the first values iterator.
+func (it *secondIterator[A, B]) Err() error {
+	return it.u.err(false)
+}
+
+// Close implements IteratorCloser[T].Close. The source pairs iterator is
+// closed when the corresponding first values iterator is closed as well.
+func (it *secondIterator[A, B]) Close() error {
+	return it.u.close(false)
+}
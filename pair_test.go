@@ -0,0 +1,127 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestPairSplit(t *testing.T) {
+	p := it.Pair[[]int, string]{
+		First:  []int{4, 2},
+		Second: "engage",
+	}
+	a, b := p.Split()
+	qt.Assert(t, qt.DeepEquals(a, []int{4, 2}))
+	qt.Assert(t, qt.Equals(b, "engage"))
+}
+
+func TestZipPairs(t *testing.T) {
+	first := it.FromSlice([][]string{{"a"}, {"b", "c"}, nil})
+	second := it.FromSlice([]map[string]int{{"a": 1}, {"b": 2}, {}, {"d": 4}})
+	iter := it.ZipPairs(first, second)
+
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []it.Pair[[]string, map[string]int]{{
+		First:  []string{"a"},
+		Second: map[string]int{"a": 1},
+	}, {
+		First:  []string{"b", "c"},
+		Second: map[string]int{"b": 2},
+	}, {
+		First:  nil,
+		Second: map[string]int{},
+	}}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.DeepEquals(iter.Value(), it.Pair[[]string, map[string]int]{}))
+}
+
+func TestZipPairsError(t *testing.T) {
+	first := it.FromSlice([][]int{{1}, {2}})
+	iter := it.ZipPairs[[]int, string](first, &errorIterator[string]{
+		v: "engage",
+	})
+
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []it.Pair[[]int, string]{{
+		First:  []int{1},
+		Second: "engage",
+	}}))
+}
+
+func TestZipPairsJoinedErrors(t *testing.T) {
+	first := &errIterator[int]{
+		err: errors.New("bad wolf"),
+	}
+	second := &errIterator[string]{
+		err: errors.New("ice"),
+	}
+	iter := it.ZipPairs[int, string](first, second)
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.ErrorMatches(iter.Err(), "bad wolf\nice"))
+
+	// Zip reports errors in the same way.
+	kvs := it.Zip[int, string](first, second)
+	qt.Assert(t, qt.IsFalse(kvs.Next()))
+	qt.Assert(t, qt.ErrorMatches(kvs.Err(), "bad wolf\nice"))
+}
+
+func TestUnzipPairs(t *testing.T) {
+	iter := it.FromSlice([]it.Pair[[]int, string]{{
+		First:  []int{1},
+		Second: "these",
+	}, {
+		First:  []int{2, 3},
+		Second: "are",
+	}})
+	first, second := it.UnzipPairs(iter)
+
+	qt.Assert(t, qt.IsTrue(second.Next()))
+	qt.Assert(t, qt.Equals(second.Value(), "these"))
+
+	got, err := it.ToSlice(first)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, [][]int{{1}, {2, 3}}))
+
+	qt.Assert(t, qt.IsTrue(second.Next()))
+	qt.Assert(t, qt.Equals(second.Value(), "are"))
+
+	qt.Assert(t, qt.IsFalse(second.Next()))
+	qt.Assert(t, qt.Equals(second.Value(), ""))
+	qt.Assert(t, qt.IsNil(second.Err()))
+}
+
+func TestZipUnzipPairsRoundtrip(t *testing.T) {
+	s := []it.Pair[func() int, int]{{
+		Second: 1,
+	}, {
+		Second: 2,
+	}}
+	got, err := it.ToSlice(it.ZipPairs(it.UnzipPairs(it.FromSlice(s))))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.HasLen(got, 2))
+	qt.Assert(t, qt.Equals(got[0].Second, 1))
+	qt.Assert(t, qt.Equals(got[1].Second, 2))
+}
+
+func TestEnumeratePairs(t *testing.T) {
+	iter := it.EnumeratePairs(it.FromSlice([][]string{{"a"}, {"b", "c"}}))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []it.Pair[int, []string]{{
+		First:  0,
+		Second: []string{"a"},
+	}, {
+		First:  1,
+		Second: []string{"b", "c"},
+	}}))
+}
@@ -0,0 +1,116 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+// PeekableIterator is an iterator that allows looking at values without
+// consuming them, and pushing values back.
+type PeekableIterator[T any] interface {
+	Iterator[T]
+
+	// Peek returns the value that will be produced by the next call to Next,
+	// without advancing the iterator. False is returned if there are no more
+	// values, in which case Err should be checked.
+	Peek() (T, bool)
+
+	// PeekN returns up to n values that will be produced by the next calls to
+	// Next, without advancing the iterator. Less than n values are returned if
+	// the iteration is done, in which case Err should be checked.
+	PeekN(n int) []T
+
+	// Unread pushes back the given value, so that it is produced by the next
+	// call to Next.
+	Unread(v T)
+}
+
+// Peekable returns an iterator producing values from the given iterator, and
+// allowing for lookahead and pushback.
+//
+// For instance:
+//
+//	lines := it.Peekable(it.Lines(r))
+//	for lines.Next() {
+//		line := lines.Value()
+//		// Join continuation lines.
+//		for next, ok := lines.Peek(); ok && strings.HasPrefix(next, " "); next, ok = lines.Peek() {
+//			lines.Next()
+//			line += next
+//		}
+//	}
+func Peekable[T any](it Iterator[T]) PeekableIterator[T] {
+	return &peeker[T]{
+		source: it,
+	}
+}
+
+type peeker[T any] struct {
+	source  Iterator[T]
+	pending []T
+	value   T
+}
+
+// Next implements Iterator[T].Next by producing pending values first.
+func (it *peeker[T]) Next() bool {
+	if len(it.pending) != 0 {
+		it.value, it.pending = it.pending[0], it.pending[1:]
+		return true
+	}
+	if it.source.Next() {
+		it.value = it.source.Value()
+		return true
+	}
+	it.value = *new(T)
+	return false
+}
+
+// Value implements Iterator[T].Value.
+func (it *peeker[T]) Value() T {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by propagating the error from the source
+// iterator.
+func (it *peeker[T]) Err() error {
+	return it.source.Err()
+}
+
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *peeker[T]) Close() error {
+	return Close(it.source)
+}
+
+// Peek implements PeekableIterator[T].Peek.
+func (it *peeker[T]) Peek() (T, bool) {
+	if !it.fill(1) {
+		return *new(T), false
+	}
+	return it.pending[0], true
+}
+
+// PeekN implements PeekableIterator[T].PeekN.
+func (it *peeker[T]) PeekN(n int) []T {
+	it.fill(n)
+	n = min(n, len(it.pending))
+	if n <= 0 {
+		return nil
+	}
+	vs := make([]T, n)
+	copy(vs, it.pending)
+	return vs
+}
+
+// Unread implements PeekableIterator[T].Unread.
+func (it *peeker[T]) Unread(v T) {
+	it.pending = append([]T{v}, it.pending...)
+}
+
+// fill advances the source iterator until at least n values are pending. It
+// reports whether there are enough pending values.
+func (it *peeker[T]) fill(n int) bool {
+	for len(it.pending) < n {
+		if !it.source.Next() {
+			return false
+		}
+		it.pending = append(it.pending, it.source.Value())
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestPeekable(t *testing.T) {
+	iter := it.Peekable(it.Count(0, 5, 1))
+
+	v, ok := iter.Peek()
+	qt.Assert(t, qt.IsTrue(ok))
+	qt.Assert(t, qt.Equals(v, 0))
+
+	// Peeking does not advance the iterator.
+	v, ok = iter.Peek()
+	qt.Assert(t, qt.IsTrue(ok))
+	qt.Assert(t, qt.Equals(v, 0))
+
+	qt.Assert(t, qt.IsTrue(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+
+	qt.Assert(t, qt.DeepEquals(iter.PeekN(2), []int{1, 2}))
+	// The value is preserved while peeking.
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+
+	qt.Assert(t, qt.IsTrue(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 1))
+
+	// Push back a couple of values.
+	iter.Unread(1)
+	iter.Unread(42)
+	qt.Assert(t, qt.DeepEquals(iter.PeekN(10), []int{42, 1, 2, 3, 4}))
+
+	got, err := it.ToSlice[int](iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{42, 1, 2, 3, 4}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+	v, ok = iter.Peek()
+	qt.Assert(t, qt.IsFalse(ok))
+	qt.Assert(t, qt.Equals(v, 0))
+	qt.Assert(t, qt.IsNil(iter.PeekN(3)))
+}
+
+func TestPeekableParse(t *testing.T) {
+	// Join continuation lines.
+	lines := it.Peekable(it.Lines(strings.NewReader("a\n b\n c\nd\n e")))
+	var got []string
+	for lines.Next() {
+		line := lines.Value()
+		for next, ok := lines.Peek(); ok && strings.HasPrefix(next, " "); next, ok = lines.Peek() {
+			lines.Next()
+			line += next
+		}
+		got = append(got, line)
+	}
+	qt.Assert(t, qt.IsNil(lines.Err()))
+	qt.Assert(t, qt.DeepEquals(got, []string{"a b c", "d e"}))
+}
+
+func TestPeekableAdapters(t *testing.T) {
+	iter := it.Peekable(it.FromSlice([]string{"these", "are", "the", "voyages"}))
+	iter.Peek()
+	got, err := it.ToSlice(it.Map[string](iter, strings.ToUpper))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []string{"THESE", "ARE", "THE", "VOYAGES"}))
+}
+
+func TestPeekableError(t *testing.T) {
+	iter := it.Peekable[string](&errorIterator[string]{
+		v: "engage",
+	})
+	qt.Assert(t, qt.DeepEquals(iter.PeekN(2), []string{"engage"}))
+	qt.Assert(t, qt.ErrorMatches(iter.Err(), "bad wolf"))
+
+	// Values already peeked are still produced.
+	qt.Assert(t, qt.IsTrue(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), "engage"))
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), ""))
+}
@@ -0,0 +1,147 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+import "iter"
+
+// Seq returns a sequence producing values from the given iterator, so that it
+// can be used in range loops. Sequences cannot report errors, so the error
+// must be checked on the iterator itself once the loop is done, for instance:
+//
+//	for v := range it.Seq(iterator) {
+//		// Do something with v.
+//	}
+//	if err := iterator.Err(); err != nil {
+//		// Handle error.
+//	}
+//
+// Breaking out of the loop does not consume further values, so that the
+// iterator can be used again afterwards.
+func Seq[T any](it Iterator[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for it.Next() {
+			if !yield(it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 returns a sequence producing key/value pairs from the given key/value
+// iterator, so that it can be used in range loops. As with Seq, the error must
+// be checked on the iterator itself once the loop is done, for instance:
+//
+//	for k, v := range it.Seq2(iterator) {
+//		// Do something with k and v.
+//	}
+//	if err := iterator.Err(); err != nil {
+//		// Handle error.
+//	}
+func Seq2[K comparable, V any](it Iterator[KeyValue[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for it.Next() {
+			if !yield(it.Value().Split()) {
+				return
+			}
+		}
+	}
+}
+
+// FromSeq returns an iterator producing values from the given sequence. The
+// returned error is always nil.
+//
+// The sequence is pulled (see iter.Pull), and resources associated with it are
+// released once the iteration is done. For this reason, the returned iterator
+// should be either consumed till the end or closed.
+func FromSeq[T any](seq iter.Seq[T]) Iterator[T] {
+	next, stop := iter.Pull(seq)
+	return &seqIterator[T]{
+		next: next,
+		stop: stop,
+	}
+}
+
+type seqIterator[T any] struct {
+	next  func() (T, bool)
+	stop  func()
+	value T
+}
+
+// Next implements Iterator[T].Next.
+func (it *seqIterator[T]) Next() bool {
+	v, ok := it.next()
+	if ok {
+		it.value = v
+		return true
+	}
+	it.value = *new(T)
+	it.stop()
+	return false
+}
+
+// Value implements Iterator[T].Value by returning values from the sequence.
+func (it *seqIterator[T]) Value() T {
+	return it.value
+}
+
+// Err implements Iterator[T].Err. The returned error is always nil.
+func (it *seqIterator[T]) Err() error {
+	return nil
+}
+
+// Close implements IteratorCloser[T].Close by stopping the sequence.
+func (it *seqIterator[T]) Close() error {
+	it.stop()
+	return nil
+}
+
+// FromSeq2 returns an iterator producing key/value pairs from the given
+// sequence. The returned error is always nil.
+//
+// As with FromSeq, the sequence is pulled and the returned iterator should be
+// either consumed till the end or closed.
+func FromSeq2[K comparable, V any](seq iter.Seq2[K, V]) Iterator[KeyValue[K, V]] {
+	next, stop := iter.Pull2(seq)
+	return &seq2Iterator[K, V]{
+		next: next,
+		stop: stop,
+	}
+}
+
+type seq2Iterator[K comparable, V any] struct {
+	next func() (K, V, bool)
+	stop func()
+	kv   KeyValue[K, V]
+}
+
+// Next implements Iterator[T].Next.
+func (it *seq2Iterator[K, V]) Next() bool {
+	k, v, ok := it.next()
+	if ok {
+		it.kv = KeyValue[K, V]{
+			Key:   k,
+			Value: v,
+		}
+		return true
+	}
+	it.kv = KeyValue[K, V]{}
+	it.stop()
+	return false
+}
+
+// Value implements Iterator[T].Value by returning key/value pairs from the
+// sequence.
+func (it *seq2Iterator[K, V]) Value() KeyValue[K, V] {
+	return it.kv
+}
+
+// Err implements Iterator[T].Err. The returned error is always nil.
+func (it *seq2Iterator[K, V]) Err() error {
+	return nil
+}
+
+// Close implements IteratorCloser[T].Close by stopping the sequence.
+func (it *seq2Iterator[K, V]) Close() error {
+	it.stop()
+	return nil
+}
@@ -0,0 +1,122 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestSeq(t *testing.T) {
+	iter := it.FromSlice([]string{"these", "are", "the", "voyages"})
+	var vs []string
+	for v := range it.Seq(iter) {
+		vs = append(vs, v)
+	}
+	qt.Assert(t, qt.IsNil(iter.Err()))
+	qt.Assert(t, qt.DeepEquals(vs, []string{"these", "are", "the", "voyages"}))
+}
+
+func TestSeqBreak(t *testing.T) {
+	iter := it.Count(0, 10, 1)
+	for v := range it.Seq(iter) {
+		if v == 3 {
+			break
+		}
+	}
+
+	// The iteration can be resumed.
+	vs, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(vs, []int{4, 5, 6, 7, 8, 9}))
+}
+
+func TestSeqError(t *testing.T) {
+	iter := &errorIterator[string]{
+		v: "engage",
+	}
+	var vs []string
+	for v := range it.Seq[string](iter) {
+		vs = append(vs, v)
+	}
+	qt.Assert(t, qt.ErrorMatches(iter.Err(), "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(vs, []string{"engage"}))
+}
+
+func TestSeq2(t *testing.T) {
+	iter := it.FromSlice(makeKeyValues())
+	m := make(map[int]string)
+	for k, v := range it.Seq2(iter) {
+		m[k] = v
+	}
+	qt.Assert(t, qt.IsNil(iter.Err()))
+	qt.Assert(t, qt.DeepEquals(m, map[int]string{
+		1:  "these",
+		2:  "are",
+		42: "the",
+		47: "voyages",
+	}))
+}
+
+func TestFromSeq(t *testing.T) {
+	iter := it.FromSeq(slices.Values([]int{1, 2, 3}))
+	vs, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(vs, []int{1, 2, 3}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+}
+
+func TestFromSeq2(t *testing.T) {
+	iter := it.FromSeq2(maps.All(map[int]string{
+		1:  "these",
+		2:  "are",
+		42: "the",
+		47: "voyages",
+	}))
+	kvs, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	slices.SortFunc(kvs, func(a, b it.KeyValue[int, string]) int {
+		return a.Key - b.Key
+	})
+	qt.Assert(t, qt.DeepEquals(kvs, makeKeyValues()))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), it.KeyValue[int, string]{}))
+}
+
+func TestSeqRoundtrip(t *testing.T) {
+	want := []string{"these", "are", "the", "voyages"}
+	got, err := it.ToSlice(it.FromSeq(it.Seq(it.FromSlice(want))))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, want))
+}
+
+func TestFromSeqClose(t *testing.T) {
+	var stopped bool
+	iter := it.FromSeq(func(yield func(int) bool) {
+		defer func() {
+			stopped = true
+		}()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	})
+	vs, err := it.ToSlice(it.Limit(iter, 3))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(vs, []int{0, 1, 2}))
+	qt.Assert(t, qt.IsFalse(stopped))
+
+	qt.Assert(t, qt.IsNil(it.Close(iter)))
+	qt.Assert(t, qt.IsTrue(stopped))
+}
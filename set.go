@@ -0,0 +1,219 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+import "errors"
+
+// The functions below implement set operations on iterators producing values
+// sorted according to the given less function, as used by MergeSorted and
+// Sorted. Two values a and b are considered equal if neither less(a, b) nor
+// less(b, a) is true. Both iterators are walked in lockstep, using constant
+// memory, and the resulting values are sorted as well. The iteration is
+// stopped when any of the two iterators has an error, in which case the errors
+// from both iterators are returned.
+//
+// Union, Intersect, Difference and SymmetricDifference implement set
+// semantics: each distinct value is produced at most once, even if repeated in
+// the given iterators. The Multiset variants take into account the number of
+// repetitions instead: for instance, a value repeated twice in the first
+// iterator and three times in the second is produced three times by
+// UnionMultiset, twice by IntersectMultiset and once by
+// SymmetricDifferenceMultiset.
+
+// Union returns an iterator producing the distinct values produced by any of
+// the two given sorted iterators.
+//
+// For instance:
+//
+//	values := it.Union(it.FromSlice([]int{1, 2, 2, 4}), it.FromSlice([]int{2, 3}), func(a, b int) bool {
+//		return a < b
+//	})
+//	// values produces 1, 2, 3, 4.
+func Union[T any](a, b Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return newSetIterator(a, b, less, true, union)
+}
+
+// UnionMultiset returns an iterator producing the values produced by any of the
+// two given sorted iterators. A value repeated m times in the first iterator
+// and n times in the second one is produced max(m, n) times.
+func UnionMultiset[T any](a, b Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return newSetIterator(a, b, less, false, union)
+}
+
+// Intersect returns an iterator producing the distinct values produced by both
+// the given sorted iterators.
+func Intersect[T any](a, b Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return newSetIterator(a, b, less, true, intersection)
+}
+
+// IntersectMultiset returns an iterator producing the values produced by both
+// the given sorted iterators. A value repeated m times in the first iterator
+// and n times in the second one is produced min(m, n) times.
+func IntersectMultiset[T any](a, b Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return newSetIterator(a, b, less, false, intersection)
+}
+
+// Difference returns an iterator producing the distinct values produced by the
+// first sorted iterator and not by the second one.
+func Difference[T any](a, b Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return newSetIterator(a, b, less, true, difference)
+}
+
+// DifferenceMultiset returns an iterator producing the values produced by the
+// first sorted iterator and not by the second one. A value repeated m times in
+// the first iterator and n times in the second one is produced m-n times, if
+// m is greater than n.
+func DifferenceMultiset[T any](a, b Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return newSetIterator(a, b, less, false, difference)
+}
+
+// SymmetricDifference returns an iterator producing the distinct values
+// produced by only one of the two given sorted iterators.
+func SymmetricDifference[T any](a, b Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return newSetIterator(a, b, less, true, symmetricDifference)
+}
+
+// SymmetricDifferenceMultiset returns an iterator producing the values produced
+// by only one of the two given sorted iterators. A value repeated m times in
+// the first iterator and n times in the second one is produced |m-n| times.
+func SymmetricDifferenceMultiset[T any](a, b Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return newSetIterator(a, b, less, false, symmetricDifference)
+}
+
+// setOperation specifies which values are produced by a set operation.
+type setOperation struct {
+	// onlyA and onlyB are true if values only present in one of the two
+	// iterators are produced.
+	onlyA, onlyB bool
+	// both is true if values present in both iterators are produced.
+	both bool
+}
+
+var (
+	union               = setOperation{onlyA: true, onlyB: true, both: true}
+	intersection        = setOperation{both: true}
+	difference          = setOperation{onlyA: true}
+	symmetricDifference = setOperation{onlyA: true, onlyB: true}
+)
+
+func newSetIterator[T any](a, b Iterator[T], less func(a, b T) bool, distinct bool, op setOperation) Iterator[T] {
+	if distinct {
+		// With no repeated values, multiset operations are set operations.
+		eq := func(x, y T) bool {
+			return !less(x, y) && !less(y, x)
+		}
+		a, b = CompactFunc(a, eq), CompactFunc(b, eq)
+	}
+	return &setIterator[T]{
+		a:    &cursor[T]{source: a},
+		b:    &cursor[T]{source: b},
+		less: less,
+		op:   op,
+	}
+}
+
+type setIterator[T any] struct {
+	a, b    *cursor[T]
+	less    func(a, b T) bool
+	op      setOperation
+	value   T
+	stopped bool
+}
+
+// Next implements Iterator[T].Next by walking both iterators in lockstep.
+func (it *setIterator[T]) Next() bool {
+	for !it.stopped {
+		okA, okB := it.a.peek(), it.b.peek()
+		if it.Err() != nil {
+			break
+		}
+		switch {
+		case !okA && !okB:
+			it.stopped = true
+		case !okB:
+			if !it.op.onlyA {
+				it.stopped = true
+				continue
+			}
+			it.value = it.a.pop()
+			return true
+		case !okA:
+			if !it.op.onlyB {
+				it.stopped = true
+				continue
+			}
+			it.value = it.b.pop()
+			return true
+		default:
+			switch {
+			case it.less(it.a.value, it.b.value):
+				v := it.a.pop()
+				if it.op.onlyA {
+					it.value = v
+					return true
+				}
+			case it.less(it.b.value, it.a.value):
+				v := it.b.pop()
+				if it.op.onlyB {
+					it.value = v
+					return true
+				}
+			default:
+				v := it.a.pop()
+				it.b.pop()
+				if it.op.both {
+					it.value = v
+					return true
+				}
+			}
+		}
+	}
+	it.value, it.stopped = *new(T), true
+	return false
+}
+
+// Value implements Iterator[T].Value by returning the result of the set
+// operation.
+func (it *setIterator[T]) Value() T {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by joining the errors from the two
+// iterators.
+func (it *setIterator[T]) Err() error {
+	return errors.Join(it.a.source.Err(), it.b.source.Err())
+}
+
+// Close implements IteratorCloser[T].Close by closing the two iterators.
+func (it *setIterator[T]) Close() error {
+	return errors.Join(Close(it.a.source), Close(it.b.source))
+}
+
+// cursor wraps an iterator allowing to look at the next value without
+// consuming it.
+type cursor[T any] struct {
+	source Iterator[T]
+	value  T
+	has    bool
+	done   bool
+}
+
+// peek advances the source iterator if required, and reports whether a value
+// is available.
+func (c *cursor[T]) peek() bool {
+	if !c.has && !c.done {
+		if c.source.Next() {
+			c.value, c.has = c.source.Value(), true
+		} else {
+			c.done = true
+		}
+	}
+	return c.has
+}
+
+// pop consumes and returns the current value.
+func (c *cursor[T]) pop() T {
+	v := c.value
+	c.value, c.has = *new(T), false
+	return v
+}
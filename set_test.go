@@ -0,0 +1,142 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+type setOperation func(a, b it.Iterator[int], less func(a, b int) bool) it.Iterator[int]
+
+var setOperationTests = []struct {
+	about string
+	op    setOperation
+	a, b  []int
+	want  []int
+}{{
+	about: "union",
+	op:    it.Union[int],
+	a:     []int{1, 2, 2, 2, 5},
+	b:     []int{0, 2, 2, 3, 6, 7},
+	want:  []int{0, 1, 2, 3, 5, 6, 7},
+}, {
+	about: "union multiset",
+	op:    it.UnionMultiset[int],
+	a:     []int{1, 2, 2, 2, 5},
+	b:     []int{0, 2, 2, 3, 6, 7},
+	want:  []int{0, 1, 2, 2, 2, 3, 5, 6, 7},
+}, {
+	about: "union empty",
+	op:    it.Union[int],
+	a:     []int{},
+	b:     []int{1, 1, 2},
+	want:  []int{1, 2},
+}, {
+	about: "intersect",
+	op:    it.Intersect[int],
+	a:     []int{1, 2, 2, 2, 5, 6},
+	b:     []int{0, 2, 2, 3, 6, 7},
+	want:  []int{2, 6},
+}, {
+	about: "intersect multiset",
+	op:    it.IntersectMultiset[int],
+	a:     []int{1, 2, 2, 2, 5, 6},
+	b:     []int{0, 2, 2, 3, 6, 7},
+	want:  []int{2, 2, 6},
+}, {
+	about: "intersect disjoint",
+	op:    it.Intersect[int],
+	a:     []int{1, 3, 5},
+	b:     []int{2, 4, 6},
+	want:  nil,
+}, {
+	about: "difference",
+	op:    it.Difference[int],
+	a:     []int{1, 2, 2, 2, 5, 6, 8, 8},
+	b:     []int{0, 2, 3, 6, 7},
+	want:  []int{1, 5, 8},
+}, {
+	about: "difference multiset",
+	op:    it.DifferenceMultiset[int],
+	a:     []int{1, 2, 2, 2, 5, 6, 8, 8},
+	b:     []int{0, 2, 3, 6, 7},
+	want:  []int{1, 2, 2, 5, 8, 8},
+}, {
+	about: "difference empty",
+	op:    it.Difference[int],
+	a:     []int{},
+	b:     []int{1, 2},
+	want:  nil,
+}, {
+	about: "symmetric difference",
+	op:    it.SymmetricDifference[int],
+	a:     []int{1, 2, 2, 2, 5, 6},
+	b:     []int{0, 2, 3, 3, 6, 7},
+	want:  []int{0, 1, 3, 5, 7},
+}, {
+	about: "symmetric difference multiset",
+	op:    it.SymmetricDifferenceMultiset[int],
+	a:     []int{1, 2, 2, 2, 5, 6},
+	b:     []int{0, 2, 3, 3, 6, 7},
+	want:  []int{0, 1, 2, 2, 3, 3, 5, 7},
+}, {
+	about: "symmetric difference equal",
+	op:    it.SymmetricDifference[int],
+	a:     []int{1, 2, 3},
+	b:     []int{1, 2, 3},
+	want:  nil,
+}}
+
+func TestSetOperations(t *testing.T) {
+	for _, test := range setOperationTests {
+		t.Run(test.about, func(t *testing.T) {
+			iter := test.op(it.FromSlice(test.a), it.FromSlice(test.b), intLess)
+			got, err := it.ToSlice(iter)
+			qt.Assert(t, qt.IsNil(err))
+			qt.Assert(t, qt.DeepEquals(got, test.want))
+
+			// Further calls to next return false and produce the zero value.
+			qt.Assert(t, qt.IsFalse(iter.Next()))
+			qt.Assert(t, qt.Equals(iter.Value(), 0))
+		})
+	}
+}
+
+func TestSetOperationsCustomOrder(t *testing.T) {
+	// Values are sorted in descending order.
+	desc := func(a, b int) bool {
+		return a > b
+	}
+	got, err := it.ToSlice(it.Union(it.FromSlice([]int{5, 3, 1}), it.FromSlice([]int{4, 3, 2}), desc))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{5, 4, 3, 2, 1}))
+}
+
+func TestSetOperationsError(t *testing.T) {
+	a := it.Chain[int](it.FromSlice([]int{1, 3}), &errorIterator[int]{v: 5})
+	b := it.FromSlice([]int{2, 3, 4, 6, 7})
+	iter := it.Union(a, b, intLess)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 2, 3, 4, 5}))
+}
+
+func TestSetOperationsBothErrors(t *testing.T) {
+	iter := it.Intersect[int](&errIterator[int]{err: errors.New("bad wolf")}, &errIterator[int]{err: errors.New("bad moon")}, intLess)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf\nbad moon"))
+	qt.Assert(t, qt.IsNil(got))
+}
+
+func TestSetOperationsClose(t *testing.T) {
+	a := &closerIterator[int]{Iterator: it.FromSlice([]int{1})}
+	b := &closerIterator[int]{Iterator: it.FromSlice([]int{2})}
+	qt.Assert(t, qt.IsNil(it.Close(it.Difference[int](a, b, intLess))))
+	qt.Assert(t, qt.Equals(a.closed, 1))
+	qt.Assert(t, qt.Equals(b.closed, 1))
+}
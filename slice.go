@@ -2,6 +2,8 @@
 
 package iterate
 
+import "slices"
+
 // FromSlice returns an iterator producing values from the given slice.
 func FromSlice[T any](s []T) Iterator[T] {
 	return &sliceIterator[T]{
@@ -41,6 +43,83 @@ func (it *sliceIterator[T]) Err() error {
 	return nil
 }
 
+// remaining returns the values not yet produced.
+func (it *sliceIterator[T]) remaining() []T {
+	if it.first || len(it.s) == 0 {
+		return it.s
+	}
+	return it.s[1:]
+}
+
+// FromSliceReverse returns an iterator producing values from the given slice
+// in reverse order.
+func FromSliceReverse[T any](s []T) Iterator[T] {
+	return &reverseSliceIterator[T]{
+		s:   s,
+		idx: len(s),
+	}
+}
+
+type reverseSliceIterator[T any] struct {
+	s []T
+	// idx is the index of the current value.
+	idx int
+}
+
+// Next implements Iterator[T].Next.
+func (it *reverseSliceIterator[T]) Next() bool {
+	if it.idx <= 0 {
+		it.s, it.idx = nil, 0
+		return false
+	}
+	it.idx--
+	return true
+}
+
+// Value implements Iterator[T].Value by returning values from a slice in
+// reverse order.
+func (it *reverseSliceIterator[T]) Value() T {
+	if it.idx >= len(it.s) {
+		return *new(T)
+	}
+	return it.s[it.idx]
+}
+
+// Err implements Iterator[T].Err. The returned error is always nil.
+func (it *reverseSliceIterator[T]) Err() error {
+	return nil
+}
+
+// Reverse returns an iterator producing the values from the given iterator in
+// reverse order. The source iterator is consumed in full on the first call to
+// Next, and all its values are held in memory. Values are not copied if the
+// given iterator has been created with FromSlice.
+//
+// For instance:
+//
+//	values := it.Reverse(it.Count(0, 5, 1))
+//	// values produces 4, 3, 2, 1, 0.
+func Reverse[T any](it Iterator[T]) Iterator[T] {
+	if s, ok := it.(*sliceIterator[T]); ok {
+		values := s.remaining()
+		// The source iterator is consumed, as it would be when collecting
+		// its values.
+		s.s = nil
+		return FromSliceReverse(values)
+	}
+	return &collector[T]{
+		source: it,
+		collect: func(it Iterator[T]) ([]T, error) {
+			values, err := ToSlice(it)
+			if err != nil {
+				return nil, err
+			}
+			slices.Reverse(values)
+			return values, nil
+		},
+	}
+}
+
 // ToSlice consumes the given iterator and returns a slice of produced values or
 // an error occurred while iterating. This function should not be used with
 // infinite iterators (see TakeWhile or Limit).
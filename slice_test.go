@@ -61,3 +61,58 @@ func TestSliceRoundtrip(t *testing.T) {
 	qt.Assert(t, qt.IsNil(err))
 	qt.Assert(t, qt.DeepEquals(got, want))
 }
+
+func TestFromSliceReverse(t *testing.T) {
+	iter := it.FromSliceReverse([]int{1, 2, 3})
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+	vs, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(vs, []int{3, 2, 1}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+
+	vs, err = it.ToSlice(it.FromSliceReverse([]int{}))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.IsNil(vs))
+}
+
+func TestReverse(t *testing.T) {
+	iter := it.Reverse(it.Count(0, 5, 1))
+	vs, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(vs, []int{4, 3, 2, 1, 0}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+}
+
+func TestReverseFromSlice(t *testing.T) {
+	s := []int{1, 2, 3, 4}
+	source := it.FromSlice(s)
+	// Values already produced are not included.
+	source.Next()
+	iter := it.Reverse(source)
+	// The slice is not copied.
+	s[3] = 42
+	vs, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(vs, []int{42, 3, 2}))
+	qt.Assert(t, qt.DeepEquals(s, []int{1, 2, 3, 42}))
+
+	// The source iterator is consumed.
+	qt.Assert(t, qt.IsFalse(source.Next()))
+	qt.Assert(t, qt.Equals(source.Value(), 0))
+
+	vs, err = it.ToSlice(it.Reverse(it.FromSlice([]int{1, 2})))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(vs, []int{2, 1}))
+}
+
+func TestReverseError(t *testing.T) {
+	vs, err := it.ToSlice(it.Reverse(it.Chain[int](it.Count(0, 3, 1), &errorIterator[int]{v: 3})))
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.IsNil(vs))
+}
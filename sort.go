@@ -0,0 +1,175 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+import (
+	"container/heap"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Sorted returns an iterator producing the values from the given iterator
+// sorted according to the given less function. Values comparing equal are
+// produced in their original order. The source iterator is consumed in full on
+// the first call to Next, and all its values are held in memory: see
+// SortExternal for sorting large amounts of values.
+//
+// For instance:
+//
+//	words := it.Sorted(it.FromSlice([]string{"these", "are", "words"}), func(a, b string) bool {
+//		return a < b
+//	})
+//	// words produces "are", "these", "words".
+func Sorted[T any](it Iterator[T], less func(a, b T) bool) Iterator[T] {
+	return &collector[T]{
+		source: it,
+		collect: func(it Iterator[T]) ([]T, error) {
+			values, err := ToSlice(it)
+			if err != nil {
+				return nil, err
+			}
+			sort.SliceStable(values, func(i, j int) bool {
+				return less(values[i], values[j])
+			})
+			return values, nil
+		},
+	}
+}
+
+// SortedBy is like Sorted, but values are sorted in ascending order of the
+// keys returned by the given function, which is called only once per value.
+// Values with equal keys are produced in their original order.
+//
+// For instance:
+//
+//	words := it.SortedBy(it.FromSlice([]string{"these", "are", "some", "words"}), func(v string) int {
+//		return len(v)
+//	})
+//	// words produces "are", "some", "these", "words".
+func SortedBy[T any, K constraints.Ordered](it Iterator[T], f func(v T) K) Iterator[T] {
+	return &collector[T]{
+		source: it,
+		collect: func(it Iterator[T]) ([]T, error) {
+			var s keySorter[T, K]
+			for it.Next() {
+				v := it.Value()
+				s.values = append(s.values, v)
+				s.keys = append(s.keys, f(v))
+			}
+			if err := it.Err(); err != nil {
+				return nil, err
+			}
+			sort.Stable(s)
+			return s.values, nil
+		},
+	}
+}
+
+// keySorter implements sort.Interface by sorting values by their keys.
+type keySorter[T any, K constraints.Ordered] struct {
+	values []T
+	keys   []K
+}
+
+func (s keySorter[T, K]) Len() int {
+	return len(s.values)
+}
+
+func (s keySorter[T, K]) Less(i, j int) bool {
+	return s.keys[i] < s.keys[j]
+}
+
+func (s keySorter[T, K]) Swap(i, j int) {
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
+
+// TopK returns an iterator producing the k greatest values from the given
+// iterator according to the given less function, in descending order. Values
+// comparing equal are produced in their original order, and earlier values are
+// preferred when not all of them can be produced. The source iterator is
+// consumed in full on the first call to Next, and at most k values are held in
+// memory.
+//
+// For instance:
+//
+//	slowest := it.TopK(requests, 10, func(a, b Request) bool {
+//		return a.Duration < b.Duration
+//	})
+//	// slowest produces the 10 requests with the longest duration.
+func TopK[T any](it Iterator[T], k int, less func(a, b T) bool) Iterator[T] {
+	if k < 0 {
+		panic("iterate: invalid number of values")
+	}
+	return &collector[T]{
+		source: it,
+		collect: func(it Iterator[T]) ([]T, error) {
+			// The heap holds the k greatest values seen so far, with the
+			// smallest one at the root. Entries are indexed by negative
+			// position, so that later values are evicted first among equal
+			// ones.
+			h := &mergeHeap[T]{
+				less: less,
+			}
+			for i := 0; it.Next(); i++ {
+				entry := mergeEntry[T]{
+					value: it.Value(),
+					idx:   -i,
+				}
+				switch {
+				case h.Len() < k:
+					heap.Push(h, entry)
+				case k > 0 && less(h.entries[0].value, entry.value):
+					h.entries[0] = entry
+					heap.Fix(h, 0)
+				}
+			}
+			if err := it.Err(); err != nil {
+				return nil, err
+			}
+			values := make([]T, h.Len())
+			for i := len(values) - 1; i >= 0; i-- {
+				values[i] = heap.Pop(h).(mergeEntry[T]).value
+			}
+			return values, nil
+		},
+	}
+}
+
+// collector is an iterator producing values collected from the source
+// iterator, which is consumed in full on the first call to Next.
+type collector[T any] struct {
+	source  Iterator[T]
+	collect func(it Iterator[T]) ([]T, error)
+	values  Iterator[T]
+	err     error
+}
+
+// Next implements Iterator[T].Next.
+func (it *collector[T]) Next() bool {
+	if it.values == nil {
+		values, err := it.collect(it.source)
+		it.values, it.err = FromSlice(values), err
+	}
+	return it.values.Next()
+}
+
+// Value implements Iterator[T].Value by returning the collected values.
+func (it *collector[T]) Value() T {
+	if it.values == nil {
+		return *new(T)
+	}
+	return it.values.Value()
+}
+
+// Err implements Iterator[T].Err by propagating the error from the source
+// iterator.
+func (it *collector[T]) Err() error {
+	return it.err
+}
+
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *collector[T]) Close() error {
+	return Close(it.source)
+}
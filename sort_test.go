@@ -0,0 +1,125 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestSorted(t *testing.T) {
+	records := makeRecords(5, 3, 8, 3, 1, 5)
+	iter := it.Sorted(it.FromSlice(records), recordLess)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []record{
+		{Key: 1, Seq: 4},
+		{Key: 3, Seq: 1},
+		{Key: 3, Seq: 3},
+		{Key: 5, Seq: 0},
+		{Key: 5, Seq: 5},
+		{Key: 8, Seq: 2},
+	}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), record{}))
+}
+
+func TestSortedError(t *testing.T) {
+	iter := it.Sorted(it.Chain[int](it.FromSlice([]int{2, 1}), &errorIterator[int]{v: 0}), intLess)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.IsNil(got))
+}
+
+func TestSortedBy(t *testing.T) {
+	calls := 0
+	iter := it.SortedBy(it.FromSlice([]string{"these", "are", "some", "words", "in", "a", "list"}), func(v string) int {
+		calls++
+		return len(v)
+	})
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []string{"a", "in", "are", "some", "list", "these", "words"}))
+	qt.Assert(t, qt.Equals(calls, 7))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), ""))
+}
+
+func TestSortedByError(t *testing.T) {
+	iter := it.SortedBy(it.Chain[int](it.FromSlice([]int{2, 1}), &errorIterator[int]{v: 0}), func(v int) int {
+		return v
+	})
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.IsNil(got))
+}
+
+func TestTopK(t *testing.T) {
+	records := makeRecords(5, 3, 8, 3, 1, 9, 5, 0, 5, 7)
+	tests := []struct {
+		k    int
+		want []record
+	}{{
+		k: 4,
+		want: []record{
+			{Key: 9, Seq: 5},
+			{Key: 8, Seq: 2},
+			{Key: 7, Seq: 9},
+			{Key: 5, Seq: 0},
+		},
+	}, {
+		k: 6,
+		want: []record{
+			{Key: 9, Seq: 5},
+			{Key: 8, Seq: 2},
+			{Key: 7, Seq: 9},
+			{Key: 5, Seq: 0},
+			{Key: 5, Seq: 6},
+			{Key: 5, Seq: 8},
+		},
+	}, {
+		k: 1,
+		want: []record{
+			{Key: 9, Seq: 5},
+		},
+	}, {
+		k:    0,
+		want: nil,
+	}}
+	for _, test := range tests {
+		iter := it.TopK(it.FromSlice(records), test.k, recordLess)
+		got, err := it.ToSlice(iter)
+		qt.Assert(t, qt.IsNil(err))
+		qt.Assert(t, qt.DeepEquals(got, test.want))
+
+		// Further calls to next return false and produce the zero value.
+		qt.Assert(t, qt.IsFalse(iter.Next()))
+		qt.Assert(t, qt.Equals(iter.Value(), record{}))
+	}
+}
+
+func TestTopKShort(t *testing.T) {
+	got, err := it.ToSlice(it.TopK(it.FromSlice([]int{2, 3, 1}), 10, intLess))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{3, 2, 1}))
+}
+
+func TestTopKError(t *testing.T) {
+	iter := it.TopK(it.Chain[int](it.FromSlice([]int{2, 1}), &errorIterator[int]{v: 0}), 2, intLess)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.IsNil(got))
+}
+
+func TestTopKInvalidSize(t *testing.T) {
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.TopK(it.FromSlice([]int{}), -1, intLess)
+	}, "iterate: invalid number of values"))
+}
@@ -0,0 +1,285 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+import "sync"
+
+// Partition returns an iterator of the values from the given iterator for
+// which predicate(v) is true, and an iterator of the values for which it is
+// false. The source iterator is consumed only once, and values are buffered
+// until retrieved by the corresponding iterator. As with Unzip, the two
+// iterators can be safely consumed from different goroutines. When closed, the
+// two iterators close the source iterator only once both of them are closed.
+//
+// For instance:
+//
+//	even, odd := it.Partition(it.Count(0, 10, 1), func(v int) bool {
+//		return v%2 == 0
+//	})
+//	// even produces 0, 2, 4, 6, 8 and odd produces 1, 3, 5, 7, 9.
+func Partition[T any](it Iterator[T], predicate func(v T) bool) (Iterator[T], Iterator[T]) {
+	p := &partitioner[T]{
+		source:    it,
+		predicate: predicate,
+	}
+	return &partitionIterator[T]{
+		p:     p,
+		match: true,
+	}, &partitionIterator[T]{
+		p: p,
+	}
+}
+
+type partitioner[T any] struct {
+	source    Iterator[T]
+	predicate func(v T) bool
+
+	// mu protects all the fields below.
+	mu sync.Mutex
+	// pending holds the values waiting to be retrieved by the matching (at
+	// index 1) and non-matching (at index 0) iterators.
+	pending [2][]T
+	closed  [2]bool
+}
+
+// next returns the next value for the matching or non-matching iterator.
+func (p *partitioner[T]) next(match bool) (T, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	idx, other := index(match), index(!match)
+	for {
+		if len(p.pending[idx]) != 0 {
+			var v T
+			v, p.pending[idx] = p.pending[idx][0], p.pending[idx][1:]
+			return v, true
+		}
+		if !p.source.Next() {
+			return *new(T), false
+		}
+		v := p.source.Value()
+		if p.predicate(v) == match {
+			return v, true
+		}
+		if !p.closed[other] {
+			p.pending[other] = append(p.pending[other], v)
+		}
+	}
+}
+
+// err returns the error from the source iterator.
+func (p *partitioner[T]) err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.source.Err()
+}
+
+// close marks the matching or non-matching iterator as closed, discarding its
+// pending values. The source iterator is closed when both iterators are
+// closed.
+func (p *partitioner[T]) close(match bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	idx := index(match)
+	if p.closed[idx] {
+		return nil
+	}
+	p.pending[idx], p.closed[idx] = nil, true
+	if p.closed[0] && p.closed[1] {
+		return Close(p.source)
+	}
+	return nil
+}
+
+// partitionIterator is the iterator returned for the matching and
+// non-matching values.
+type partitionIterator[T any] struct {
+	p     *partitioner[T]
+	match bool
+	value T
+}
+
+// Next implements Iterator[T].Next.
+func (it *partitionIterator[T]) Next() bool {
+	var ok bool
+	it.value, ok = it.p.next(it.match)
+	return ok
+}
+
+// Value implements Iterator[T].Value by returning values from the source
+// iterator.
+func (it *partitionIterator[T]) Value() T {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by propagating the error from the source
+// iterator.
+func (it *partitionIterator[T]) Err() error {
+	return it.p.err()
+}
+
+// Close implements IteratorCloser[T].Close. The source iterator is closed when
+// the other iterator is closed as well.
+func (it *partitionIterator[T]) Close() error {
+	return it.p.close(it.match)
+}
+
+// Span returns an iterator producing values from the given iterator while
+// predicate(v) is true, as TakeWhile does, and an iterator producing the
+// remaining values, starting from the first value for which the predicate is
+// false. The source iterator is consumed only once: if the remaining values
+// are consumed first, the prefix values are buffered until retrieved. The two
+// iterators can be safely consumed from different goroutines. When closed, the
+// two iterators close the source iterator only once both of them are closed.
+//
+// For instance:
+//
+//	prefix, rest := it.Span(it.FromSlice([]int{1, 2, 3, 1, 2}), func(idx, v int) bool {
+//		return v < 3
+//	})
+//	// prefix produces 1, 2 and rest produces 3, 1, 2.
+func Span[T any](it Iterator[T], predicate func(idx int, v T) bool) (Iterator[T], Iterator[T]) {
+	s := &spanner[T]{
+		source:    it,
+		predicate: predicate,
+	}
+	return &spanIterator[T]{
+		s:      s,
+		prefix: true,
+	}, &spanIterator[T]{
+		s: s,
+	}
+}
+
+// Break is like Span, but the prefix includes values while predicate(v) is
+// false, and the remaining values start from the first value for which the
+// predicate is true.
+func Break[T any](it Iterator[T], predicate func(idx int, v T) bool) (Iterator[T], Iterator[T]) {
+	return Span(it, func(idx int, v T) bool {
+		return !predicate(idx, v)
+	})
+}
+
+type spanner[T any] struct {
+	source    Iterator[T]
+	predicate func(idx int, v T) bool
+
+	// mu protects all the fields below.
+	mu sync.Mutex
+	// idx is the index of the next value to be checked with the predicate.
+	idx int
+	// pending holds the values waiting to be retrieved by the prefix (at index
+	// 1) and the rest (at index 0) iterators.
+	pending [2][]T
+	closed  [2]bool
+	// split is true when the first value not satisfying the predicate has
+	// been found, or when the source iterator is exhausted.
+	split bool
+}
+
+// next returns the next value for the prefix or the rest iterator.
+func (s *spanner[T]) next(prefix bool) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx := index(prefix)
+	for {
+		if len(s.pending[idx]) != 0 {
+			var v T
+			v, s.pending[idx] = s.pending[idx][0], s.pending[idx][1:]
+			return v, true
+		}
+		if s.split && prefix {
+			return *new(T), false
+		}
+		if !s.source.Next() {
+			s.split = true
+			return *new(T), false
+		}
+		v := s.source.Value()
+		if s.split {
+			// Only the rest iterator can get here.
+			return v, true
+		}
+		if s.predicate(s.idx, v) {
+			s.idx++
+			if prefix {
+				return v, true
+			}
+			if !s.closed[1] {
+				s.pending[1] = append(s.pending[1], v)
+			}
+			continue
+		}
+		s.split = true
+		if !prefix {
+			return v, true
+		}
+		if !s.closed[0] {
+			s.pending[0] = append(s.pending[0], v)
+		}
+	}
+}
+
+// err returns the error from the source iterator.
+func (s *spanner[T]) err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.source.Err()
+}
+
+// close marks the prefix or the rest iterator as closed, discarding its
+// pending values. The source iterator is closed when both iterators are
+// closed.
+func (s *spanner[T]) close(prefix bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	idx := index(prefix)
+	if s.closed[idx] {
+		return nil
+	}
+	s.pending[idx], s.closed[idx] = nil, true
+	if s.closed[0] && s.closed[1] {
+		return Close(s.source)
+	}
+	return nil
+}
+
+// spanIterator is the iterator returned for the prefix and the remaining
+// values.
+type spanIterator[T any] struct {
+	s      *spanner[T]
+	prefix bool
+	value  T
+}
+
+// Next implements Iterator[T].Next.
+func (it *spanIterator[T]) Next() bool {
+	var ok bool
+	it.value, ok = it.s.next(it.prefix)
+	return ok
+}
+
+// Value implements Iterator[T].Value by returning values from the source
+// iterator.
+func (it *spanIterator[T]) Value() T {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by propagating the error from the source
+// iterator.
+func (it *spanIterator[T]) Err() error {
+	return it.s.err()
+}
+
+// Close implements IteratorCloser[T].Close. The source iterator is closed when
+// the other iterator is closed as well.
+func (it *spanIterator[T]) Close() error {
+	return it.s.close(it.prefix)
+}
+
+// index returns 1 if b is true, 0 otherwise.
+func index(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
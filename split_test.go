@@ -0,0 +1,184 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestPartition(t *testing.T) {
+	even, odd := it.Partition(it.Count(0, 10, 1), func(v int) bool {
+		return v%2 == 0
+	})
+
+	qt.Assert(t, qt.IsTrue(odd.Next()))
+	qt.Assert(t, qt.Equals(odd.Value(), 1))
+
+	got, err := it.ToSlice(even)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{0, 2, 4, 6, 8}))
+
+	got, err = it.ToSlice(odd)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{3, 5, 7, 9}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(even.Next()))
+	qt.Assert(t, qt.Equals(even.Value(), 0))
+}
+
+func TestPartitionError(t *testing.T) {
+	matching, others := it.Partition[string](&errorIterator[string]{
+		v: "engage",
+	}, func(v string) bool {
+		return v == "engage"
+	})
+	got, err := it.ToSlice(others)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.IsNil(got))
+
+	got, err = it.ToSlice(matching)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []string{"engage"}))
+}
+
+func TestPartitionClose(t *testing.T) {
+	source := &closerIterator[int]{
+		Iterator: it.Count(0, 10, 1),
+	}
+	even, odd := it.Partition[int](source, func(v int) bool {
+		return v%2 == 0
+	})
+	qt.Assert(t, qt.IsNil(it.Close(odd)))
+	qt.Assert(t, qt.Equals(source.closed, 0))
+
+	got, err := it.ToSlice(even)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{0, 2, 4, 6, 8}))
+
+	qt.Assert(t, qt.IsNil(it.Close(even)))
+	qt.Assert(t, qt.Equals(source.closed, 1))
+}
+
+func TestPartitionConcurrent(t *testing.T) {
+	even, odd := it.Partition(it.Count(0, 1000, 1), func(v int) bool {
+		return v%2 == 0
+	})
+	var wg sync.WaitGroup
+	var esum, osum int
+	var eerr, oerr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		esum, eerr = it.Sum(even)
+	}()
+	go func() {
+		defer wg.Done()
+		osum, oerr = it.Sum(odd)
+	}()
+	wg.Wait()
+	qt.Assert(t, qt.IsNil(eerr))
+	qt.Assert(t, qt.IsNil(oerr))
+	qt.Assert(t, qt.Equals(esum, 249500))
+	qt.Assert(t, qt.Equals(osum, 250000))
+}
+
+func TestSpan(t *testing.T) {
+	prefix, rest := it.Span(it.FromSlice([]int{1, 2, 3, 1, 2}), func(idx, v int) bool {
+		return v < 3
+	})
+
+	got, err := it.ToSlice(prefix)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 2}))
+
+	got, err = it.ToSlice(rest)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{3, 1, 2}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(prefix.Next()))
+	qt.Assert(t, qt.Equals(prefix.Value(), 0))
+	qt.Assert(t, qt.IsFalse(rest.Next()))
+	qt.Assert(t, qt.Equals(rest.Value(), 0))
+}
+
+func TestSpanRestFirst(t *testing.T) {
+	prefix, rest := it.Span(it.FromSlice([]int{1, 2, 3, 1, 2}), func(idx, v int) bool {
+		return idx < 2
+	})
+
+	got, err := it.ToSlice(rest)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{3, 1, 2}))
+
+	// Prefix values have been buffered.
+	got, err = it.ToSlice(prefix)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 2}))
+}
+
+func TestSpanInterleaved(t *testing.T) {
+	prefix, rest := it.Span(it.FromSlice([]string{"a", "b", "C", "d"}), func(idx int, v string) bool {
+		return v >= "a"
+	})
+	qt.Assert(t, qt.IsTrue(prefix.Next()))
+	qt.Assert(t, qt.Equals(prefix.Value(), "a"))
+
+	qt.Assert(t, qt.IsTrue(rest.Next()))
+	qt.Assert(t, qt.Equals(rest.Value(), "C"))
+
+	qt.Assert(t, qt.IsTrue(prefix.Next()))
+	qt.Assert(t, qt.Equals(prefix.Value(), "b"))
+	qt.Assert(t, qt.IsFalse(prefix.Next()))
+
+	qt.Assert(t, qt.IsTrue(rest.Next()))
+	qt.Assert(t, qt.Equals(rest.Value(), "d"))
+	qt.Assert(t, qt.IsFalse(rest.Next()))
+}
+
+func TestSpanAllMatching(t *testing.T) {
+	prefix, rest := it.Span(it.Count(0, 3, 1), func(idx, v int) bool {
+		return true
+	})
+	got, err := it.ToSlice(rest)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.IsNil(got))
+
+	got, err = it.ToSlice(prefix)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{0, 1, 2}))
+}
+
+func TestSpanError(t *testing.T) {
+	prefix, rest := it.Span[string](&errorIterator[string]{
+		v: "engage",
+	}, func(idx int, v string) bool {
+		return true
+	})
+	got, err := it.ToSlice(prefix)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []string{"engage"}))
+
+	got, err = it.ToSlice(rest)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.IsNil(got))
+}
+
+func TestBreak(t *testing.T) {
+	header, body := it.Break(it.FromSlice([]string{"a: 1", "b: 2", "", "body"}), func(idx int, v string) bool {
+		return v == ""
+	})
+	got, err := it.ToSlice(header)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []string{"a: 1", "b: 2"}))
+
+	got, err = it.ToSlice(body)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []string{"", "body"}))
+}
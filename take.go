@@ -2,6 +2,8 @@
 
 package iterate
 
+import "math"
+
 // DropWhile returns an iterator discarding values from the given iterator while
 // predicate(v) is true.
 func DropWhile[T any](it Iterator[T], predicate func(idx int, v T) bool) Iterator[T] {
@@ -32,6 +34,11 @@ func (it *dropper[T]) Next() bool {
 	return false
 }
 
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *dropper[T]) Close() error {
+	return Close(it.Iterator)
+}
+
 // TakeWhile returns an iterator producing values from the given iterator while
 // predicate(v) is true.
 func TakeWhile[T any](it Iterator[T], predicate func(idx int, v T) bool) Iterator[T] {
@@ -73,6 +80,11 @@ func (it *taker[T]) Err() error {
 	return it.source.Err()
 }
 
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *taker[T]) Close() error {
+	return Close(it.source)
+}
+
 // Limit returns an iterator limiting the number of values returned by the given
 // iterator.
 func Limit[T any](it Iterator[T], limit int) Iterator[T] {
@@ -86,3 +98,279 @@ func Next[T any](it Iterator[T]) (T, error) {
 	it.Next()
 	return it.Value(), it.Err()
 }
+
+// Skip returns an iterator discarding the first n values from the given
+// iterator.
+func Skip[T any](it Iterator[T], n int) Iterator[T] {
+	return DropWhile(it, func(idx int, v T) bool {
+		return idx < n
+	})
+}
+
+// StepBy returns an iterator producing every n-th value from the given
+// iterator, starting from the first one. It panics if n is less than 1.
+//
+// For instance:
+//
+//	values := it.StepBy(it.Count(0, 10, 1), 3)
+//	// values produces 0, 3, 6, 9.
+func StepBy[T any](it Iterator[T], n int) Iterator[T] {
+	if n < 1 {
+		panic("iterate: invalid step")
+	}
+	return Slice(it, 0, math.MaxInt, n)
+}
+
+// Slice returns an iterator producing the values from the given iterator with
+// indexes from start to stop (excluded), stepping by step, with the same
+// semantics as slicing a sequence in Python. In particular, negative start
+// and stop indexes are relative to the end of the iteration, out of range
+// indexes are clamped, and a negative step produces values in reverse order.
+// Use math.MaxInt as stop for slicing until the end with a positive step, and
+// math.MinInt for slicing until the beginning with a negative step. It panics
+// if step is zero.
+//
+// Values are streamed when start is not negative and step is positive, in
+// which case only -stop values are held in memory if stop is negative.
+// Otherwise, the source iterator is consumed in full on the first call to
+// Next, and all its values are held in memory.
+//
+// For instance:
+//
+//	values := it.Slice(it.Count(0, 10, 1), 1, -2, 3)
+//	// values produces 1, 4, 7.
+//
+//	reversed := it.Slice(it.Count(0, 10, 1), -1, math.MinInt, -4)
+//	// reversed produces 9, 5, 1.
+func Slice[T any](it Iterator[T], start, stop, step int) Iterator[T] {
+	switch {
+	case step == 0:
+		panic("iterate: invalid step")
+	case start >= 0 && step > 0:
+		switch {
+		case stop == math.MinInt:
+			// No sequence is long enough to include values before stop, and
+			// -stop would overflow.
+			stop = 0
+		case stop < 0:
+			it, stop = DropLast(it, -stop), math.MaxInt
+		}
+		return &slicer[T]{
+			source: it,
+			start:  start,
+			stop:   stop,
+			step:   step,
+		}
+	}
+	return &collector[T]{
+		source: it,
+		collect: func(it Iterator[T]) ([]T, error) {
+			values, err := ToSlice(it)
+			if err != nil {
+				return nil, err
+			}
+			start, stop := sliceIndexes(len(values), start, stop, step)
+			var s []T
+			for i := start; step > 0 && i < stop || step < 0 && i > stop; i += step {
+				s = append(s, values[i])
+				if step > 0 && stop-i <= step || step < 0 && stop-i >= step {
+					break
+				}
+			}
+			return s, nil
+		},
+	}
+}
+
+// sliceIndexes returns the start and stop indexes for slicing a sequence of
+// length n, normalized and clamped as in Python.
+func sliceIndexes(n, start, stop, step int) (int, int) {
+	lower, upper := 0, n
+	if step < 0 {
+		lower, upper = -1, n-1
+	}
+	clamp := func(idx int) int {
+		if idx < 0 {
+			return max(idx+n, lower)
+		}
+		return min(idx, upper)
+	}
+	return clamp(start), clamp(stop)
+}
+
+type slicer[T any] struct {
+	source            Iterator[T]
+	start, stop, step int
+	// idx is the index of the next value from the source iterator.
+	idx     int
+	stopped bool
+}
+
+// Next implements Iterator[T].Next by skipping values not included in the
+// slice.
+func (it *slicer[T]) Next() bool {
+	for !it.stopped {
+		if it.idx >= it.stop || !it.source.Next() {
+			it.stopped = true
+			break
+		}
+		idx := it.idx
+		it.idx++
+		if idx >= it.start && (idx-it.start)%it.step == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Value implements Iterator[T].Value by returning values included in the
+// slice.
+func (it *slicer[T]) Value() T {
+	if it.stopped {
+		return *new(T)
+	}
+	return it.source.Value()
+}
+
+// Err implements Iterator[T].Err by propagating the error from the source
+// iterator.
+func (it *slicer[T]) Err() error {
+	return it.source.Err()
+}
+
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *slicer[T]) Close() error {
+	return Close(it.source)
+}
+
+// Last consumes the given iterator and returns the last value produced. The
+// returned boolean is false if the iterator produces no values, or if it has
+// an error.
+//
+// For instance:
+//
+//	line, ok, err := it.Last(it.Lines(f)) // line is the last line in f.
+func Last[T any](it Iterator[T]) (v T, ok bool, err error) {
+	for it.Next() {
+		v, ok = it.Value(), true
+	}
+	if err := it.Err(); err != nil {
+		return *new(T), false, err
+	}
+	return v, ok, nil
+}
+
+// Nth returns the value at index n produced by the iterator, discarding the
+// previous values. As with Next, the zero value is returned if the iterator
+// produces n values or less. It panics if n is negative.
+func Nth[T any](it Iterator[T], n int) (T, error) {
+	if n < 0 {
+		panic("iterate: invalid index")
+	}
+	return Next(Skip(it, n))
+}
+
+// TakeLast returns an iterator producing the last n values from the given
+// iterator. The source iterator is consumed in full on the first call to
+// Next, and at most n values are held in memory, in a ring buffer. It panics
+// if n is negative.
+//
+// For instance:
+//
+//	values := it.TakeLast(it.Count(0, 10, 1), 3)
+//	// values produces 7, 8, 9.
+func TakeLast[T any](it Iterator[T], n int) Iterator[T] {
+	if n < 0 {
+		panic("iterate: invalid number of values")
+	}
+	return &collector[T]{
+		source: it,
+		collect: func(it Iterator[T]) ([]T, error) {
+			var ring []T
+			pos := 0
+			for it.Next() {
+				switch {
+				case len(ring) < n:
+					ring = append(ring, it.Value())
+				case n > 0:
+					ring[pos], pos = it.Value(), (pos+1)%n
+				}
+			}
+			if err := it.Err(); err != nil {
+				return nil, err
+			}
+			// The oldest value is at pos.
+			return append(ring[pos:], ring[:pos]...), nil
+		},
+	}
+}
+
+// DropLast returns an iterator producing the values from the given iterator
+// except for the last n ones. Values are streamed, with n values held in
+// memory, in a ring buffer. It panics if n is negative.
+//
+// For instance:
+//
+//	values := it.DropLast(it.Count(0, 10, 1), 3)
+//	// values produces 0, 1, 2, 3, 4, 5, 6.
+func DropLast[T any](it Iterator[T], n int) Iterator[T] {
+	if n < 0 {
+		panic("iterate: invalid number of values")
+	}
+	return &lastDropper[T]{
+		source: it,
+		n:      n,
+	}
+}
+
+type lastDropper[T any] struct {
+	source Iterator[T]
+	n      int
+	// ring holds the last n values produced by the source iterator, with the
+	// oldest one at pos.
+	ring    []T
+	pos     int
+	value   T
+	stopped bool
+}
+
+// Next implements Iterator[T].Next by producing values once the ring buffer
+// is full.
+func (it *lastDropper[T]) Next() bool {
+	for !it.stopped && len(it.ring) < it.n {
+		if !it.source.Next() {
+			it.stopped = true
+			break
+		}
+		it.ring = append(it.ring, it.source.Value())
+	}
+	if it.stopped || !it.source.Next() {
+		it.value, it.ring, it.stopped = *new(T), nil, true
+		return false
+	}
+	v := it.source.Value()
+	if it.n == 0 {
+		it.value = v
+		return true
+	}
+	it.value, it.ring[it.pos] = it.ring[it.pos], v
+	it.pos = (it.pos + 1) % it.n
+	return true
+}
+
+// Value implements Iterator[T].Value by returning values from the source
+// iterator.
+func (it *lastDropper[T]) Value() T {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by propagating the error from the source
+// iterator.
+func (it *lastDropper[T]) Err() error {
+	return it.source.Err()
+}
+
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *lastDropper[T]) Close() error {
+	return Close(it.source)
+}
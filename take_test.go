@@ -3,6 +3,9 @@
 package iterate_test
 
 import (
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/go-quicktest/qt"
@@ -93,3 +96,199 @@ func TestNext(t *testing.T) {
 	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
 	qt.Assert(t, qt.Equals(v, ""))
 }
+
+func TestSkip(t *testing.T) {
+	vs, err := it.ToSlice(it.Skip(it.Count(0, 5, 1), 3))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(vs, []int{3, 4}))
+
+	vs, err = it.ToSlice(it.Skip(it.Count(0, 5, 1), 10))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.IsNil(vs))
+}
+
+func TestStepBy(t *testing.T) {
+	vs, err := it.ToSlice(it.StepBy(it.Count(0, 10, 1), 3))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(vs, []int{0, 3, 6, 9}))
+
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.StepBy(it.Count(0, 10, 1), 0)
+	}, "iterate: invalid step"))
+}
+
+func TestSlice(t *testing.T) {
+	tests := []struct {
+		start, stop, step int
+		want              []int
+	}{
+		{start: 2, stop: 5, step: 1, want: []int{2, 3, 4}},
+		{start: 1, stop: math.MaxInt, step: 3, want: []int{1, 4, 7}},
+		{start: 0, stop: 20, step: 4, want: []int{0, 4, 8}},
+		{start: 5, stop: 2, step: 1, want: nil},
+		{start: 12, stop: 20, step: 1, want: nil},
+		{start: 1, stop: -2, step: 3, want: []int{1, 4, 7}},
+		{start: 0, stop: -20, step: 1, want: nil},
+		{start: -3, stop: math.MaxInt, step: 1, want: []int{7, 8, 9}},
+		{start: -3, stop: 9, step: 1, want: []int{7, 8}},
+		{start: -3, stop: -1, step: 1, want: []int{7, 8}},
+		{start: -20, stop: 2, step: 1, want: []int{0, 1}},
+		{start: -1, stop: math.MinInt, step: -1, want: []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}},
+		{start: -1, stop: math.MinInt, step: -4, want: []int{9, 5, 1}},
+		{start: 20, stop: 6, step: -2, want: []int{9, 7}},
+		{start: 3, stop: -8, step: -1, want: []int{3}},
+		{start: 3, stop: 5, step: -1, want: nil},
+		{start: math.MaxInt, stop: math.MinInt, step: math.MinInt, want: []int{9}},
+		{start: 0, stop: math.MaxInt, step: math.MaxInt, want: []int{0}},
+		{start: -10, stop: math.MaxInt, step: math.MaxInt, want: []int{0}},
+		{start: 0, stop: math.MinInt, step: 1, want: nil},
+		{start: math.MaxInt, stop: math.MinInt, step: 1, want: nil},
+		{start: 0, stop: math.MinInt + 1, step: 1, want: nil},
+		{start: 0, stop: -10, step: 1, want: nil},
+		{start: 0, stop: -9, step: 1, want: []int{0}},
+		{start: math.MinInt, stop: math.MaxInt, step: 1, want: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{start: math.MinInt, stop: math.MinInt, step: 1, want: nil},
+		{start: math.MaxInt, stop: math.MaxInt, step: 1, want: nil},
+		{start: math.MinInt, stop: math.MaxInt, step: -1, want: nil},
+		{start: math.MaxInt, stop: math.MinInt, step: -1, want: []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}},
+		{start: 0, stop: math.MaxInt, step: math.MinInt, want: nil},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d:%d:%d", test.start, test.stop, test.step), func(t *testing.T) {
+			iter := it.Slice(it.Count(0, 10, 1), test.start, test.stop, test.step)
+			vs, err := it.ToSlice(iter)
+			qt.Assert(t, qt.IsNil(err))
+			qt.Assert(t, qt.DeepEquals(vs, test.want))
+
+			// Further calls to next return false and produce the zero value.
+			qt.Assert(t, qt.IsFalse(iter.Next()))
+			qt.Assert(t, qt.Equals(iter.Value(), 0))
+		})
+	}
+}
+
+func TestSliceError(t *testing.T) {
+	source := func() it.Iterator[int] {
+		return it.Chain[int](it.Count(0, 3, 1), &errorIterator[int]{v: 3})
+	}
+	vs, err := it.ToSlice(it.Slice(source(), 1, math.MaxInt, 1))
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(vs, []int{1, 2, 3}))
+
+	vs, err = it.ToSlice(it.Slice(source(), -1, math.MinInt, -1))
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.IsNil(vs))
+}
+
+func TestSliceInvalidStep(t *testing.T) {
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.Slice(it.Count(0, 10, 1), 0, 5, 0)
+	}, "iterate: invalid step"))
+}
+
+func TestNth(t *testing.T) {
+	v, err := it.Nth(it.Count(10, 20, 1), 3)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.Equals(v, 13))
+
+	v, err = it.Nth(it.Count(10, 20, 1), 10)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.Equals(v, 0))
+
+	_, err = it.Nth[int](&errorIterator[int]{v: 42}, 1)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.Nth(it.Count(10, 20, 1), -1)
+	}, "iterate: invalid index"))
+}
+
+func TestTakeLast(t *testing.T) {
+	tests := []struct {
+		stop, n int
+		want    []int
+	}{
+		{stop: 10, n: 3, want: []int{7, 8, 9}},
+		{stop: 2, n: 3, want: []int{0, 1}},
+		{stop: 3, n: 3, want: []int{0, 1, 2}},
+		{stop: 10, n: 0, want: nil},
+		{stop: 0, n: 3, want: nil},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d, %d", test.stop, test.n), func(t *testing.T) {
+			iter := it.TakeLast(it.Count(0, test.stop, 1), test.n)
+			vs, err := it.ToSlice(iter)
+			qt.Assert(t, qt.IsNil(err))
+			qt.Assert(t, qt.DeepEquals(vs, test.want))
+
+			// Further calls to next return false and produce the zero value.
+			qt.Assert(t, qt.IsFalse(iter.Next()))
+			qt.Assert(t, qt.Equals(iter.Value(), 0))
+		})
+	}
+}
+
+func TestTakeLastError(t *testing.T) {
+	vs, err := it.ToSlice(it.TakeLast(it.Chain[int](it.Count(0, 3, 1), &errorIterator[int]{v: 3}), 2))
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.IsNil(vs))
+}
+
+func TestDropLast(t *testing.T) {
+	tests := []struct {
+		stop, n int
+		want    []int
+	}{
+		{stop: 10, n: 3, want: []int{0, 1, 2, 3, 4, 5, 6}},
+		{stop: 2, n: 3, want: nil},
+		{stop: 3, n: 3, want: nil},
+		{stop: 4, n: 3, want: []int{0}},
+		{stop: 3, n: 0, want: []int{0, 1, 2}},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d, %d", test.stop, test.n), func(t *testing.T) {
+			iter := it.DropLast(it.Count(0, test.stop, 1), test.n)
+			vs, err := it.ToSlice(iter)
+			qt.Assert(t, qt.IsNil(err))
+			qt.Assert(t, qt.DeepEquals(vs, test.want))
+
+			// Further calls to next return false and produce the zero value.
+			qt.Assert(t, qt.IsFalse(iter.Next()))
+			qt.Assert(t, qt.Equals(iter.Value(), 0))
+		})
+	}
+}
+
+func TestDropLastError(t *testing.T) {
+	vs, err := it.ToSlice(it.DropLast(it.Chain[int](it.Count(0, 3, 1), &errorIterator[int]{v: 3}), 2))
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(vs, []int{0, 1}))
+}
+
+func TestPositionalInvalidSize(t *testing.T) {
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.TakeLast(it.Count(0, 10, 1), -1)
+	}, "iterate: invalid number of values"))
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.DropLast(it.Count(0, 10, 1), -1)
+	}, "iterate: invalid number of values"))
+}
+
+func TestLast(t *testing.T) {
+	v, ok, err := it.Last(it.Lines(strings.NewReader("these\nare\nthe voyages\n")))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.IsTrue(ok))
+	qt.Assert(t, qt.Equals(v, "the voyages"))
+
+	v, ok, err = it.Last(it.FromSlice([]string{}))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.IsFalse(ok))
+	qt.Assert(t, qt.Equals(v, ""))
+}
+
+func TestLastError(t *testing.T) {
+	v, ok, err := it.Last(it.Chain[int](it.Count(0, 3, 1), &errorIterator[int]{v: 3}))
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.IsFalse(ok))
+	qt.Assert(t, qt.Equals(v, 0))
+}
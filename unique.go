@@ -0,0 +1,67 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+// Unique returns an iterator producing values from the given iterator,
+// skipping values already produced. Values already produced are held in
+// memory: see Compact for dropping only consecutive duplicates in constant
+// memory.
+func Unique[T comparable](it Iterator[T]) Iterator[T] {
+	return UniqueBy(it, func(v T) T {
+		return v
+	})
+}
+
+// UniqueBy returns an iterator producing values from the given iterator,
+// skipping values with a key, computed using the given function, already seen.
+// Only the first value for each key is produced. Keys already seen are held in
+// memory.
+//
+// For instance:
+//
+//	words := it.FromSlice([]string{"these", "are", "the", "voyages"})
+//	// Produce the first word for each length.
+//	words = it.UniqueBy(words, func(v string) int {
+//		return len(v)
+//	})
+//	// words produces "these", "are", "voyages".
+func UniqueBy[T any, K comparable](it Iterator[T], f func(v T) K) Iterator[T] {
+	seen := make(map[K]struct{})
+	return Filter(it, func(v T) bool {
+		key := f(v)
+		if _, ok := seen[key]; ok {
+			return false
+		}
+		seen[key] = struct{}{}
+		return true
+	})
+}
+
+// Compact returns an iterator producing values from the given iterator,
+// skipping consecutive duplicates. Only the first value of each run of equal
+// values is produced.
+//
+// For instance:
+//
+//	values := it.Compact(it.FromSlice([]int{1, 1, 2, 3, 3, 1}))
+//	// values produces 1, 2, 3, 1.
+func Compact[T comparable](it Iterator[T]) Iterator[T] {
+	return CompactFunc(it, func(a, b T) bool {
+		return a == b
+	})
+}
+
+// CompactFunc is like Compact, but the given function is used to compare
+// values. The function is called with the last produced value and the
+// candidate value.
+func CompactFunc[T any](it Iterator[T], eq func(a, b T) bool) Iterator[T] {
+	var last T
+	var started bool
+	return Filter(it, func(v T) bool {
+		if started && eq(last, v) {
+			return false
+		}
+		last, started = v, true
+		return true
+	})
+}
@@ -0,0 +1,76 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestUnique(t *testing.T) {
+	iter := it.Unique(it.FromSlice([]int{1, 2, 1, 3, 2, 2, 4}))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 2, 3, 4}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+}
+
+func TestUniqueError(t *testing.T) {
+	iter := it.Unique(it.Chain[string](it.FromSlice([]string{"a", "a"}), &errorIterator[string]{
+		v: "b",
+	}))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []string{"a", "b"}))
+}
+
+func TestUniqueBy(t *testing.T) {
+	words := it.FromSlice([]string{"these", "are", "the", "voyages", "The", "ARE"})
+	iter := it.UniqueBy(words, strings.ToLower)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []string{"these", "are", "the", "voyages"}))
+}
+
+func TestCompact(t *testing.T) {
+	iter := it.Compact(it.FromSlice([]int{1, 1, 2, 3, 3, 3, 1, 1}))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{1, 2, 3, 1}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+}
+
+func TestCompactZeroValues(t *testing.T) {
+	got, err := it.ToSlice(it.Compact(it.FromSlice([]string{"", "", "a", ""})))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []string{"", "a", ""}))
+}
+
+func TestCompactFunc(t *testing.T) {
+	lines := it.Lines(strings.NewReader("Hello\nhello\nHELLO\nworld\nWorld"))
+	iter := it.CompactFunc(lines, strings.EqualFold)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []string{"Hello", "world"}))
+}
+
+func TestCompactFuncError(t *testing.T) {
+	iter := it.CompactFunc(it.Chain[int](it.Count(0, 2, 1), &errorIterator[int]{
+		v: 1,
+	}), func(a, b int) bool {
+		return a == b
+	})
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []int{0, 1}))
+}
@@ -0,0 +1,209 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+// Chunk returns an iterator producing slices of n consecutive values from the
+// given iterator. The last slice may be shorter than n. It panics if n is less
+// than 1.
+//
+// For instance:
+//
+//	chunks := it.Chunk(it.Count(0, 5, 1), 2)
+//	for chunks.Next() {
+//		v := chunks.Value()
+//		// v is [0, 1], then [2, 3], then [4].
+//	}
+func Chunk[T any](it Iterator[T], n int) Iterator[[]T] {
+	if n < 1 {
+		panic("iterate: invalid chunk size")
+	}
+	return &chunker[T]{
+		source: it,
+		n:      n,
+	}
+}
+
+type chunker[T any] struct {
+	source Iterator[T]
+	n      int
+	chunk  []T
+}
+
+// Next implements Iterator[T].Next by collecting the next chunk of values.
+func (it *chunker[T]) Next() bool {
+	it.chunk = nil
+	for len(it.chunk) < it.n && it.source.Next() {
+		if it.chunk == nil {
+			it.chunk = make([]T, 0, it.n)
+		}
+		it.chunk = append(it.chunk, it.source.Value())
+	}
+	return len(it.chunk) != 0
+}
+
+// Value implements Iterator[T].Value by returning chunks of values.
+func (it *chunker[T]) Value() []T {
+	return it.chunk
+}
+
+// Err implements Iterator[T].Err by propagating the error from the source
+// iterator.
+func (it *chunker[T]) Err() error {
+	return it.source.Err()
+}
+
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *chunker[T]) Close() error {
+	return Close(it.source)
+}
+
+// Window returns an iterator producing overlapping sliding windows of n
+// consecutive values from the given iterator. No windows are produced if the
+// given iterator produces less than n values. It panics if n is less than 1.
+//
+// For instance:
+//
+//	windows := it.Window(it.Count(0, 4, 1), 2)
+//	for windows.Next() {
+//		v := windows.Value()
+//		// v is [0, 1], then [1, 2], then [2, 3].
+//	}
+//
+// Each window is a newly allocated slice. See WindowReuse for avoiding
+// allocations.
+func Window[T any](it Iterator[T], n int) Iterator[[]T] {
+	return newWindower(it, n, false)
+}
+
+// WindowReuse is like Window, but the same slice is reused for all the
+// windows, so that no allocations are required while iterating. For this
+// reason, the window returned by Value is only valid until the next call to
+// Next.
+func WindowReuse[T any](it Iterator[T], n int) Iterator[[]T] {
+	return newWindower(it, n, true)
+}
+
+func newWindower[T any](it Iterator[T], n int, reuse bool) *windower[T] {
+	if n < 1 {
+		panic("iterate: invalid window size")
+	}
+	return &windower[T]{
+		source: it,
+		n:      n,
+		reuse:  reuse,
+	}
+}
+
+type windower[T any] struct {
+	source  Iterator[T]
+	n       int
+	reuse   bool
+	window  []T
+	stopped bool
+}
+
+// Next implements Iterator[T].Next by sliding the window forward.
+func (it *windower[T]) Next() bool {
+	if it.stopped {
+		return false
+	}
+	if it.window == nil {
+		// Fill the first window.
+		window := make([]T, 0, it.n)
+		for len(window) < it.n && it.source.Next() {
+			window = append(window, it.source.Value())
+		}
+		if len(window) < it.n {
+			it.stopped = true
+			return false
+		}
+		it.window = window
+		return true
+	}
+	if !it.source.Next() {
+		it.window, it.stopped = nil, true
+		return false
+	}
+	window := it.window
+	if !it.reuse {
+		window = make([]T, it.n)
+	}
+	copy(window, it.window[1:])
+	window[it.n-1] = it.source.Value()
+	it.window = window
+	return true
+}
+
+// Value implements Iterator[T].Value by returning sliding windows of values.
+func (it *windower[T]) Value() []T {
+	return it.window
+}
+
+// Err implements Iterator[T].Err by propagating the error from the source
+// iterator.
+func (it *windower[T]) Err() error {
+	return it.source.Err()
+}
+
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *windower[T]) Close() error {
+	return Close(it.source)
+}
+
+// Pairwise returns an iterator producing pairs of adjacent values from the
+// given iterator. No pairs are produced if the given iterator produces less
+// than two values.
+//
+// For instance:
+//
+//	pairs := it.Pairwise(it.FromSlice([]string{"a", "b", "c"}))
+//	for pairs.Next() {
+//		kv := pairs.Value()
+//		// kv is ("a", "b"), then ("b", "c").
+//	}
+func Pairwise[T comparable](it Iterator[T]) Iterator[KeyValue[T, T]] {
+	return &pairwiser[T]{
+		source: it,
+	}
+}
+
+type pairwiser[T comparable] struct {
+	source  Iterator[T]
+	kv      KeyValue[T, T]
+	started bool
+	stopped bool
+}
+
+// Next implements Iterator[T].Next.
+func (it *pairwiser[T]) Next() bool {
+	if !it.stopped && !it.started {
+		it.started = true
+		if it.source.Next() {
+			it.kv.Value = it.source.Value()
+		} else {
+			it.stopped = true
+		}
+	}
+	if it.stopped || !it.source.Next() {
+		it.kv, it.stopped = KeyValue[T, T]{}, true
+		return false
+	}
+	it.kv.Key, it.kv.Value = it.kv.Value, it.source.Value()
+	return true
+}
+
+// Value implements Iterator[T].Value by returning pairs of adjacent values.
+func (it *pairwiser[T]) Value() KeyValue[T, T] {
+	return it.kv
+}
+
+// Err implements Iterator[T].Err by propagating the error from the source
+// iterator.
+func (it *pairwiser[T]) Err() error {
+	return it.source.Err()
+}
+
+// Close implements IteratorCloser[T].Close by closing the source iterator.
+func (it *pairwiser[T]) Close() error {
+	return Close(it.source)
+}
@@ -0,0 +1,163 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		stop, n int
+		want    [][]int
+	}{{
+		stop: 5,
+		n:    2,
+		want: [][]int{{0, 1}, {2, 3}, {4}},
+	}, {
+		stop: 6,
+		n:    3,
+		want: [][]int{{0, 1, 2}, {3, 4, 5}},
+	}, {
+		stop: 2,
+		n:    5,
+		want: [][]int{{0, 1}},
+	}, {
+		stop: 0,
+		n:    1,
+		want: nil,
+	}}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d, %d", test.stop, test.n), func(t *testing.T) {
+			iter := it.Chunk(it.Count(0, test.stop, 1), test.n)
+			got, err := it.ToSlice(iter)
+			qt.Assert(t, qt.IsNil(err))
+			qt.Assert(t, qt.DeepEquals(got, test.want))
+
+			// Further calls to next return false and produce the zero value.
+			qt.Assert(t, qt.IsFalse(iter.Next()))
+			qt.Assert(t, qt.IsNil(iter.Value()))
+		})
+	}
+}
+
+func TestChunkError(t *testing.T) {
+	iter := it.Chunk(it.Chain[int](it.Count(0, 3, 1), &errorIterator[int]{v: 3}), 2)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, [][]int{{0, 1}, {2, 3}}))
+}
+
+func TestChunkInvalidSize(t *testing.T) {
+	qt.Assert(t, qt.PanicMatches(func() {
+		it.Chunk(it.Count(0, 3, 1), 0)
+	}, "iterate: invalid chunk size"))
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		stop, n int
+		want    [][]int
+	}{{
+		stop: 4,
+		n:    2,
+		want: [][]int{{0, 1}, {1, 2}, {2, 3}},
+	}, {
+		stop: 5,
+		n:    3,
+		want: [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}},
+	}, {
+		stop: 3,
+		n:    1,
+		want: [][]int{{0}, {1}, {2}},
+	}, {
+		stop: 3,
+		n:    3,
+		want: [][]int{{0, 1, 2}},
+	}, {
+		stop: 2,
+		n:    3,
+		want: nil,
+	}}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%d, %d", test.stop, test.n), func(t *testing.T) {
+			iter := it.Window(it.Count(0, test.stop, 1), test.n)
+			got, err := it.ToSlice(iter)
+			qt.Assert(t, qt.IsNil(err))
+			qt.Assert(t, qt.DeepEquals(got, test.want))
+
+			// Further calls to next return false and produce the zero value.
+			qt.Assert(t, qt.IsFalse(iter.Next()))
+			qt.Assert(t, qt.IsNil(iter.Value()))
+		})
+	}
+}
+
+func TestWindowReuse(t *testing.T) {
+	iter := it.WindowReuse(it.Count(0, 5, 1), 3)
+	var got [][]int
+	var first []int
+	for iter.Next() {
+		w := iter.Value()
+		if first == nil {
+			first = w
+		}
+		// The same underlying array is reused.
+		qt.Assert(t, qt.Equals(&w[0], &first[0]))
+		got = append(got, append([]int(nil), w...))
+	}
+	qt.Assert(t, qt.IsNil(iter.Err()))
+	qt.Assert(t, qt.DeepEquals(got, [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}}))
+}
+
+func TestWindowError(t *testing.T) {
+	iter := it.Window(it.Chain[int](it.Count(0, 3, 1), &errorIterator[int]{v: 3}), 3)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, [][]int{{0, 1, 2}, {1, 2, 3}}))
+}
+
+func TestPairwise(t *testing.T) {
+	iter := it.Pairwise(it.FromSlice([]string{"a", "b", "c"}))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []it.KeyValue[string, string]{{
+		Key:   "a",
+		Value: "b",
+	}, {
+		Key:   "b",
+		Value: "c",
+	}}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), it.KeyValue[string, string]{}))
+}
+
+func TestPairwiseShort(t *testing.T) {
+	got, err := it.ToSlice(it.Pairwise(it.FromSlice([]int{42})))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.IsNil(got))
+
+	got, err = it.ToSlice(it.Pairwise(it.FromSlice([]int{})))
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.IsNil(got))
+}
+
+func TestPairwiseError(t *testing.T) {
+	iter := it.Pairwise(it.Chain[int](it.Count(0, 2, 1), &errorIterator[int]{v: 2}))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []it.KeyValue[int, int]{{
+		Key:   0,
+		Value: 1,
+	}, {
+		Key:   1,
+		Value: 2,
+	}}))
+}
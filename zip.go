@@ -0,0 +1,204 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate
+
+import "errors"
+
+// ZipLongest returns an iterator of pairs produced by the given iterators. The
+// longer of the two iterators is used, and values missing from the shorter one
+// are replaced with the given fill values. The iteration is stopped when any
+// of the two iterators has an error, in which case the errors from both
+// iterators are returned.
+//
+// For instance:
+//
+//	first := it.FromSlice([]string{"a", "b", "c"})
+//	second := it.FromSlice([]int{1})
+//	pairs := it.ZipLongest(first, second, "", -1)
+//	for pairs.Next() {
+//		p := pairs.Value()
+//		// p is ("a", 1), then ("b", -1), then ("c", -1).
+//	}
+func ZipLongest[A, B any](first Iterator[A], second Iterator[B], fillFirst A, fillSecond B) Iterator[Pair[A, B]] {
+	return &longestZipper[A, B]{
+		first:      first,
+		second:     second,
+		fillFirst:  fillFirst,
+		fillSecond: fillSecond,
+	}
+}
+
+type longestZipper[A, B any] struct {
+	first                 Iterator[A]
+	second                Iterator[B]
+	fillFirst             A
+	fillSecond            B
+	firstDone, secondDone bool
+	stopped               bool
+}
+
+// Next implements Iterator[T].Next.
+func (it *longestZipper[A, B]) Next() bool {
+	if it.stopped {
+		return false
+	}
+	if !it.firstDone {
+		it.firstDone = !it.first.Next()
+	}
+	if !it.secondDone {
+		it.secondDone = !it.second.Next()
+	}
+	if (it.firstDone && it.secondDone) || it.Err() != nil {
+		it.stopped = true
+		return false
+	}
+	return true
+}
+
+// Value implements Iterator[T].Value by returning pairs, filling values for
+// exhausted iterators.
+func (it *longestZipper[A, B]) Value() Pair[A, B] {
+	if it.stopped {
+		return Pair[A, B]{}
+	}
+	p := Pair[A, B]{
+		First:  it.fillFirst,
+		Second: it.fillSecond,
+	}
+	if !it.firstDone {
+		p.First = it.first.Value()
+	}
+	if !it.secondDone {
+		p.Second = it.second.Value()
+	}
+	return p
+}
+
+// Err implements Iterator[T].Err by joining the errors from the first and
+// second iterators.
+func (it *longestZipper[A, B]) Err() error {
+	return errors.Join(it.first.Err(), it.second.Err())
+}
+
+// Close implements IteratorCloser[T].Close by closing the first and second
+// iterators.
+func (it *longestZipper[A, B]) Close() error {
+	return errors.Join(Close(it.first), Close(it.second))
+}
+
+// ZipN returns an iterator of slices holding the values produced by each of
+// the given iterators, in order. The shortest of the iterators is used. The
+// errors from all the iterators are returned. Each produced slice is newly
+// allocated.
+//
+// For instance:
+//
+//	values := it.ZipN(it.Count(0, 3, 1), it.Count(10, 13, 1), it.Count(20, 30, 1))
+//	for values.Next() {
+//		v := values.Value()
+//		// v is [0, 10, 20], then [1, 11, 21], then [2, 12, 22].
+//	}
+func ZipN[T any](its ...Iterator[T]) Iterator[[]T] {
+	return &nZipper[T]{
+		sources: its,
+	}
+}
+
+type nZipper[T any] struct {
+	sources []Iterator[T]
+	values  []T
+	stopped bool
+}
+
+// Next implements Iterator[T].Next.
+func (it *nZipper[T]) Next() bool {
+	it.values = nil
+	if it.stopped || len(it.sources) == 0 {
+		it.stopped = true
+		return false
+	}
+	values := make([]T, len(it.sources))
+	for i, source := range it.sources {
+		if !source.Next() {
+			it.stopped = true
+			return false
+		}
+		values[i] = source.Value()
+	}
+	it.values = values
+	return true
+}
+
+// Value implements Iterator[T].Value by returning slices of values.
+func (it *nZipper[T]) Value() []T {
+	return it.values
+}
+
+// Err implements Iterator[T].Err by joining the errors from all the source
+// iterators.
+func (it *nZipper[T]) Err() error {
+	errs := make([]error, 0, len(it.sources))
+	for _, source := range it.sources {
+		errs = append(errs, source.Err())
+	}
+	return errors.Join(errs...)
+}
+
+// Close implements IteratorCloser[T].Close by closing all the source
+// iterators.
+func (it *nZipper[T]) Close() error {
+	return closeAll(it.sources)
+}
+
+// ZipWith returns an iterator producing the results of calling f with values
+// from the given iterators. The shorter of the two iterators is used. The
+// errors from both iterators are returned.
+//
+// For instance:
+//
+//	sums := it.ZipWith(it.Count(0, 3, 1), it.Count(10, 13, 1), func(a, b int) int {
+//		return a + b
+//	})
+//	// sums produces 10, then 12, then 14.
+func ZipWith[A, B, C any](first Iterator[A], second Iterator[B], f func(a A, b B) C) Iterator[C] {
+	return &withZipper[A, B, C]{
+		first:  first,
+		second: second,
+		f:      f,
+	}
+}
+
+type withZipper[A, B, C any] struct {
+	first   Iterator[A]
+	second  Iterator[B]
+	f       func(a A, b B) C
+	value   C
+	stopped bool
+}
+
+// Next implements Iterator[T].Next.
+func (it *withZipper[A, B, C]) Next() bool {
+	if it.stopped || !it.first.Next() || !it.second.Next() {
+		it.value, it.stopped = *new(C), true
+		return false
+	}
+	it.value = it.f(it.first.Value(), it.second.Value())
+	return true
+}
+
+// Value implements Iterator[T].Value by returning combined values.
+func (it *withZipper[A, B, C]) Value() C {
+	return it.value
+}
+
+// Err implements Iterator[T].Err by joining the errors from the first and
+// second iterators.
+func (it *withZipper[A, B, C]) Err() error {
+	return errors.Join(it.first.Err(), it.second.Err())
+}
+
+// Close implements IteratorCloser[T].Close by closing the first and second
+// iterators.
+func (it *withZipper[A, B, C]) Close() error {
+	return errors.Join(Close(it.first), Close(it.second))
+}
@@ -0,0 +1,132 @@
+// Licensed under the MIT license, see LICENSE file for details.
+
+package iterate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-quicktest/qt"
+
+	it "github.com/frankban/iterate"
+)
+
+func TestZipLongest(t *testing.T) {
+	first := it.FromSlice([]string{"a", "b", "c"})
+	second := it.FromSlice([]int{1})
+	iter := it.ZipLongest(first, second, "", -1)
+
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []it.Pair[string, int]{{
+		First:  "a",
+		Second: 1,
+	}, {
+		First:  "b",
+		Second: -1,
+	}, {
+		First:  "c",
+		Second: -1,
+	}}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), it.Pair[string, int]{}))
+}
+
+func TestZipLongestFirstShorter(t *testing.T) {
+	iter := it.ZipLongest(it.Count(0, 1, 1), it.Count(0, 3, 1), 42, 47)
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []it.Pair[int, int]{
+		{First: 0, Second: 0},
+		{First: 42, Second: 1},
+		{First: 42, Second: 2},
+	}))
+}
+
+func TestZipLongestError(t *testing.T) {
+	iter := it.ZipLongest[int, string](
+		it.Count(0, 3, 1),
+		&errorIterator[string]{v: "engage"},
+		0, "")
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+	qt.Assert(t, qt.DeepEquals(got, []it.Pair[int, string]{{
+		First:  0,
+		Second: "engage",
+	}}))
+}
+
+func TestZipN(t *testing.T) {
+	iter := it.ZipN(it.Count(0, 3, 1), it.Count(10, 13, 1), it.Count(20, 30, 1))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, [][]int{{0, 10, 20}, {1, 11, 21}, {2, 12, 22}}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.IsNil(iter.Value()))
+}
+
+func TestZipNNoIterators(t *testing.T) {
+	got, err := it.ToSlice(it.ZipN[int]())
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.IsNil(got))
+}
+
+func TestZipNError(t *testing.T) {
+	iter := it.ZipN[int](
+		&errorIterator[int]{v: 1},
+		&errorIterator[int]{v: 2},
+		it.Count(0, 10, 1))
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.DeepEquals(got, [][]int{{1, 2, 0}}))
+	// The other iterators are not advanced once the first one fails.
+	qt.Assert(t, qt.ErrorMatches(err, "bad wolf"))
+}
+
+func TestZipWith(t *testing.T) {
+	iter := it.ZipWith(it.Count(0, 3, 1), it.Count(10, 20, 1), func(a, b int) int {
+		return a + b
+	})
+	got, err := it.ToSlice(iter)
+	qt.Assert(t, qt.IsNil(err))
+	qt.Assert(t, qt.DeepEquals(got, []int{10, 12, 14}))
+
+	// Further calls to next return false and produce the zero value.
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.Equals(iter.Value(), 0))
+}
+
+func TestZipWithJoinedErrors(t *testing.T) {
+	first := &errIterator[int]{
+		err: errors.New("bad wolf"),
+	}
+	second := &errIterator[string]{
+		err: errors.New("ice"),
+	}
+	iter := it.ZipWith[int, string](first, second, func(a int, b string) string {
+		return b
+	})
+	qt.Assert(t, qt.IsFalse(iter.Next()))
+	qt.Assert(t, qt.ErrorMatches(iter.Err(), "bad wolf\nice"))
+}
+
+// errIterator is an iterator producing no values and returning the given
+// error.
+type errIterator[T any] struct {
+	err error
+}
+
+func (it *errIterator[T]) Next() bool {
+	return false
+}
+
+func (it *errIterator[T]) Value() T {
+	return *new(T)
+}
+
+func (it *errIterator[T]) Err() error {
+	return it.err
+}